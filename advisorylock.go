@@ -0,0 +1,56 @@
+package sqlutil
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// WithAdvisoryLock runs the given function while holding the session-level advisory lock named name,
+// on a connection dedicated to the function.
+// It waits until the lock is acquired or ctx is done.
+// If the lock cannot be released, the connection is discarded so that the lock does not leak.
+func WithAdvisoryLock(ctx context.Context, db *sql.DB, dialect Dialect, name string, f func(context.Context, *sql.Conn) error) (err error) {
+	lockQuery, unlockQuery, err := dialect.AdvisoryLockSQL()
+	if err != nil {
+		return err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if dialect == DialectMySQL {
+		acquired, err := QueryScalar[sql.NullInt64](ctx, conn, lockQuery, name)
+		if err != nil {
+			return fmt.Errorf("failed to acquire advisory lock: %w", err)
+		}
+		if acquired.Int64 != 1 {
+			return fmt.Errorf("failed to acquire advisory lock: %s", name)
+		}
+	} else {
+		if _, err := execContext(ctx, conn, lockQuery, name); err != nil {
+			return fmt.Errorf("failed to acquire advisory lock: %w", err)
+		}
+	}
+
+	defer func() {
+		// should release the lock even if ctx is done
+		if _, uerr := execContext(context.WithoutCancel(ctx), conn, unlockQuery, name); uerr != nil {
+			conn.Raw(func(any) error {
+				return driver.ErrBadConn
+			})
+
+			if err == nil {
+				err = fmt.Errorf("failed to release advisory lock: %w", uerr)
+			}
+		}
+	}()
+
+	err = f(ctx, conn)
+
+	return
+}
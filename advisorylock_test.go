@@ -0,0 +1,89 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestWithAdvisoryLock(t *testing.T) {
+	tcs := []struct {
+		name    string
+		db      *sql.DB
+		dialect sqlutil.Dialect
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			sqlutil.DialectMySQL,
+		},
+		{
+			"postgresql",
+			psqlDB,
+			sqlutil.DialectPostgreSQL,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("failure: unsupported dialect", func(t *testing.T) {
+				ctx := t.Context()
+
+				err := sqlutil.WithAdvisoryLock(ctx, tc.db, sqlutil.DialectSQLite, "lock", func(ctx context.Context, conn *sql.Conn) error {
+					return nil
+				})
+				require.ErrorContains(t, err, "unsupported dialect: advisory lock: sqlite")
+			})
+
+			t.Run("failure: wait until ctx is done", func(t *testing.T) {
+				ctx := t.Context()
+
+				err := sqlutil.WithAdvisoryLock(ctx, tc.db, tc.dialect, "lock", func(ctx context.Context, conn *sql.Conn) error {
+					ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+					defer cancel()
+
+					return sqlutil.WithAdvisoryLock(ctx, tc.db, tc.dialect, "lock", func(ctx context.Context, conn *sql.Conn) error {
+						return nil
+					})
+				})
+				require.ErrorContains(t, err, "failed to acquire advisory lock")
+			})
+
+			t.Run("failure: error from function", func(t *testing.T) {
+				ctx := t.Context()
+
+				errSomethingWentWrong := errors.New("something went wrong")
+
+				err := sqlutil.WithAdvisoryLock(ctx, tc.db, tc.dialect, "lock", func(ctx context.Context, conn *sql.Conn) error {
+					return errSomethingWentWrong
+				})
+				require.ErrorIs(t, err, errSomethingWentWrong)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				var called bool
+				err := sqlutil.WithAdvisoryLock(ctx, tc.db, tc.dialect, "lock", func(ctx context.Context, conn *sql.Conn) error {
+					called = true
+
+					return conn.PingContext(ctx)
+				})
+				require.NoError(t, err)
+				require.True(t, called)
+
+				// the lock should have been released
+				err = sqlutil.WithAdvisoryLock(ctx, tc.db, tc.dialect, "lock", func(ctx context.Context, conn *sql.Conn) error {
+					return nil
+				})
+				require.NoError(t, err)
+			})
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package sqlutil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// BulkInsertOption configures how BulkInsert inserts rows.
+type BulkInsertOption func(*bulkInsertConfig)
+
+type bulkInsertConfig struct {
+	batchSize  int
+	transact   bool
+	onConflict *onConflictConfig
+}
+
+type onConflictConfig struct {
+	conflictColumns []string
+	updateColumns   []string
+	updateAll       bool
+	doNothing       bool
+}
+
+// WithBatchSize limits the number of rows inserted by a single statement.
+// By default, a statement contains as many rows as the dialect's placeholder limit allows.
+func WithBatchSize(n int) BulkInsertOption {
+	return func(conf *bulkInsertConfig) {
+		conf.batchSize = n
+	}
+}
+
+// WithTransaction makes all statements run within a single transaction by Transact,
+// so that either all rows or none are inserted.
+// The query executor must implement TxStarter.
+func WithTransaction() BulkInsertOption {
+	return func(conf *bulkInsertConfig) {
+		conf.transact = true
+	}
+}
+
+// WithOnConflictUpdate makes rows conflicting on conflictColumns update updateColumns
+// with the values being inserted, turning the INSERT into an upsert.
+// See Dialect.UpsertSQL for the clause generated for each dialect.
+//
+// Note that MySQL counts an updated row as 2 rows affected (0 if unchanged),
+// and PostgreSQL rejects a statement that updates the same row twice,
+// so the rows should not conflict with each other.
+func WithOnConflictUpdate(conflictColumns []string, updateColumns ...string) BulkInsertOption {
+	return func(conf *bulkInsertConfig) {
+		conf.onConflict = &onConflictConfig{
+			conflictColumns: conflictColumns,
+			updateColumns:   updateColumns,
+		}
+	}
+}
+
+// WithOnConflictUpdateAll is like WithOnConflictUpdate,
+// but updates all the inserted columns other than conflictColumns.
+func WithOnConflictUpdateAll(conflictColumns ...string) BulkInsertOption {
+	return func(conf *bulkInsertConfig) {
+		conf.onConflict = &onConflictConfig{
+			conflictColumns: conflictColumns,
+			updateAll:       true,
+		}
+	}
+}
+
+// WithOnConflictDoNothing makes rows conflicting on conflictColumns skipped.
+// Skipped rows are not counted as rows affected.
+func WithOnConflictDoNothing(conflictColumns ...string) BulkInsertOption {
+	return func(conf *bulkInsertConfig) {
+		conf.onConflict = &onConflictConfig{
+			conflictColumns: conflictColumns,
+			doNothing:       true,
+		}
+	}
+}
+
+// BulkInsert inserts rows into the table with multi-row INSERT statements,
+// splitting them into batches so that no statement exceeds the dialect's placeholder limit,
+// and returns the total number of rows affected.
+// Each row must have one value per column.
+//
+// Without WithTransaction, the batches are inserted one by one,
+// and the rows of the batches preceding a failed one remain inserted.
+func BulkInsert(ctx context.Context, queryExecutor QueryExecutor, dialect Dialect, table Fragment, columns []string, rows [][]any, opts ...BulkInsertOption) (int64, error) {
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return 0, fmt.Errorf("invalid row: index %d: %d values for %d columns", i, len(row), len(columns))
+		}
+	}
+
+	return bulkInsert(ctx, queryExecutor, dialect, table, columns, rows, opts...)
+}
+
+// BulkInsertStructs is like BulkInsert, but inserts structs (or pointers to structs) of type T.
+// The columns are derived from the `db` tags as in ScanOne,
+// excluding fields with the auto option, which the database is expected to generate.
+func BulkInsertStructs[T any](ctx context.Context, queryExecutor QueryExecutor, dialect Dialect, table Fragment, vs []T, opts ...BulkInsertOption) (int64, error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if !isStructType(typ) {
+		return 0, fmt.Errorf("unsupported type: %s", reflect.TypeFor[T]())
+	}
+
+	meta, err := structMetaOf(typ)
+	if err != nil {
+		return 0, err
+	}
+
+	var fields []fieldMeta
+	for _, fm := range meta.fields {
+		if !fm.auto {
+			fields = append(fields, fm)
+		}
+	}
+
+	columns := make([]string, len(fields))
+	for i, fm := range fields {
+		columns[i] = fm.name
+	}
+
+	rows := make([][]any, len(vs))
+	for i, v := range vs {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return 0, fmt.Errorf("invalid row: index %d: nil %s", i, rv.Type())
+			}
+			rv = rv.Elem()
+		}
+
+		row := make([]any, len(fields))
+		for j, fm := range fields {
+			fv, err := rv.FieldByIndexErr(fm.index)
+			if err != nil {
+				// through a nil embedded pointer
+				continue
+			}
+
+			row[j] = fv.Interface()
+		}
+		rows[i] = row
+	}
+
+	return bulkInsert(ctx, queryExecutor, dialect, table, columns, rows, opts...)
+}
+
+func bulkInsert(ctx context.Context, queryExecutor QueryExecutor, dialect Dialect, table Fragment, columns []string, rows [][]any, opts ...BulkInsertOption) (int64, error) {
+	var conf bulkInsertConfig
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	if err := dialect.validate(); err != nil {
+		return 0, err
+	}
+	if table.IsZero() {
+		return 0, errors.New("invalid table: empty")
+	}
+	if len(columns) == 0 {
+		return 0, errors.New("invalid columns: empty")
+	}
+
+	batchSize := dialect.MaxPlaceholders() / len(columns)
+	if batchSize == 0 {
+		return 0, fmt.Errorf("invalid columns: %d columns exceed the placeholder limit", len(columns))
+	}
+	if conf.batchSize < 0 {
+		return 0, fmt.Errorf("invalid batch size: %d", conf.batchSize)
+	}
+	if conf.batchSize > 0 {
+		batchSize = min(batchSize, conf.batchSize)
+	}
+
+	var suffix string
+	if conf.onConflict != nil {
+		var err error
+		if suffix, err = conf.onConflict.clause(dialect, columns); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	insert := func(ctx context.Context, queryExecutor QueryExecutor) (int64, error) {
+		var total int64
+		for start := 0; start < len(rows); start += batchSize {
+			batch := rows[start:min(start+batchSize, len(rows))]
+
+			query, args := buildInsertSQL(dialect, table, columns, batch)
+			if suffix != "" {
+				query += " " + suffix
+			}
+
+			res, err := execContext(ctx, queryExecutor, query, args...)
+			if err != nil {
+				return total, fmt.Errorf("failed to insert rows: %w", err)
+			}
+
+			n, err := res.RowsAffected()
+			if err != nil {
+				return total, fmt.Errorf("failed to get rows affected: %w", err)
+			}
+			total += n
+		}
+
+		return total, nil
+	}
+
+	if !conf.transact {
+		return insert(ctx, queryExecutor)
+	}
+
+	txStarter, ok := queryExecutor.(TxStarter)
+	if !ok {
+		return 0, fmt.Errorf("invalid query executor: %T does not implement TxStarter", queryExecutor)
+	}
+
+	var total int64
+	if err := Transact(ctx, txStarter, func(ctx context.Context, tx *sql.Tx) error {
+		n, err := insert(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		total = n
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// clause returns the upsert clause for the inserted columns.
+func (conf *onConflictConfig) clause(dialect Dialect, columns []string) (string, error) {
+	if conf.doNothing {
+		return dialect.UpsertSQL(conf.conflictColumns, nil)
+	}
+
+	updateColumns := conf.updateColumns
+	if conf.updateAll {
+		for _, col := range columns {
+			if !slices.Contains(conf.conflictColumns, col) {
+				updateColumns = append(updateColumns, col)
+			}
+		}
+	}
+	if len(updateColumns) == 0 {
+		return "", errors.New("invalid upsert: no columns to update")
+	}
+
+	return dialect.UpsertSQL(conf.conflictColumns, updateColumns)
+}
+
+// buildInsertSQL builds a multi-row INSERT statement and its arguments.
+func buildInsertSQL(dialect Dialect, table Fragment, columns []string, rows [][]any) (string, []any) {
+	var sb strings.Builder
+
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(table.String())
+	sb.WriteString(" (")
+	for i, col := range columns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(dialect.QuoteIdentifier(col))
+	}
+	sb.WriteString(") VALUES ")
+
+	args := make([]any, 0, len(rows)*len(columns))
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteByte('(')
+		for j, v := range row {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			args = append(args, v)
+			sb.WriteString(dialect.Placeholder(len(args)))
+		}
+		sb.WriteByte(')')
+	}
+
+	return sb.String(), args
+}
@@ -0,0 +1,196 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type bulkTestTask struct {
+	ID          int             `db:"id"`
+	Title       string          `db:"title"`
+	URL         sqlutil.HTTPURL `db:"url"`
+	IsCompleted bool            `db:"is_completed,auto"`
+}
+
+func TestBulkInsert(t *testing.T) {
+	tcs := []struct {
+		name    string
+		db      *sql.DB
+		dialect sqlutil.Dialect
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			sqlutil.DialectMySQL,
+		},
+		{
+			"postgresql",
+			psqlDB,
+			sqlutil.DialectPostgreSQL,
+		},
+	}
+
+	columns := []string{"id", "title", "url"}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			table := tc.dialect.Identifier("task")
+
+			t.Run("failure: invalid row", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.BulkInsert(ctx, tc.db, tc.dialect, table, columns, [][]any{
+					{3, "task3"},
+				})
+				require.ErrorContains(t, err, "invalid row: index 0: 2 values for 3 columns")
+			})
+
+			t.Run("failure: empty columns", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.BulkInsert(ctx, tc.db, tc.dialect, table, nil, [][]any{})
+				require.ErrorContains(t, err, "invalid columns: empty")
+			})
+
+			t.Run("failure: transaction without TxStarter", func(t *testing.T) {
+				ctx := t.Context()
+
+				tx, err := tc.db.BeginTx(ctx, nil)
+				require.NoError(t, err)
+				defer tx.Rollback()
+
+				_, err = sqlutil.BulkInsert(ctx, tx, tc.dialect, table, columns, [][]any{
+					{3, "task3", "http://m0t0k1ch1.com/task/3"},
+				}, sqlutil.WithTransaction())
+				require.ErrorContains(t, err, "does not implement TxStarter")
+			})
+
+			t.Run("failure: rollback on duplicate key", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.BulkInsert(ctx, tc.db, tc.dialect, table, columns, [][]any{
+					{3, "task3", "http://m0t0k1ch1.com/task/3"},
+					{1, "task1", "http://m0t0k1ch1.com/task/1"},
+				}, sqlutil.WithBatchSize(1), sqlutil.WithTransaction())
+				require.ErrorContains(t, err, "failed to insert rows")
+				require.Equal(t, sqlutil.ErrorKindUniqueViolation, tc.dialect.ClassifyError(err))
+
+				require.Equal(t, 2, countAllTasks(t, ctx, tc.db))
+			})
+
+			t.Run("failure: upsert without columns to update", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.BulkInsert(ctx, tc.db, tc.dialect, table, []string{"id"}, [][]any{
+					{1},
+				}, sqlutil.WithOnConflictUpdateAll("id"))
+				require.ErrorContains(t, err, "invalid upsert: no columns to update")
+			})
+
+			t.Run("success: no rows", func(t *testing.T) {
+				ctx := t.Context()
+
+				n, err := sqlutil.BulkInsert(ctx, tc.db, tc.dialect, table, columns, nil)
+				require.NoError(t, err)
+				require.Zero(t, n)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				n, err := sqlutil.BulkInsert(ctx, tc.db, tc.dialect, table, columns, [][]any{
+					{3, "task3", "http://m0t0k1ch1.com/task/3"},
+					{4, "task4", "http://m0t0k1ch1.com/task/4"},
+					{5, "task5", "http://m0t0k1ch1.com/task/5"},
+				}, sqlutil.WithBatchSize(2))
+				require.NoError(t, err)
+				require.Equal(t, int64(3), n)
+
+				require.Equal(t, 5, countAllTasks(t, ctx, tc.db))
+			})
+
+			t.Run("success: upsert", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.BulkInsert(ctx, tc.db, tc.dialect, table, columns, [][]any{
+					{1, "task1 updated", "http://m0t0k1ch1.com/task/1/updated"},
+					{6, "task6", "http://m0t0k1ch1.com/task/6"},
+				}, sqlutil.WithOnConflictUpdate([]string{"id"}, "title"))
+				require.NoError(t, err)
+
+				title, err := sqlutil.QueryScalar[string](ctx, tc.db, `SELECT title FROM task WHERE id = 1`)
+				require.NoError(t, err)
+				require.Equal(t, "task1 updated", title)
+
+				url, err := sqlutil.QueryScalar[string](ctx, tc.db, `SELECT url FROM task WHERE id = 1`)
+				require.NoError(t, err)
+				require.Equal(t, "http://m0t0k1ch1.com/task/1", url)
+
+				require.Equal(t, 6, countAllTasks(t, ctx, tc.db))
+			})
+
+			t.Run("success: upsert all", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.BulkInsertStructs(ctx, tc.db, tc.dialect, table, []bulkTestTask{
+					{
+						ID:    2,
+						Title: "task2 updated",
+						URL:   sqlutil.MustNewHTTPURLFromString("https://m0t0k1ch1.com/task/2/updated"),
+					},
+				}, sqlutil.WithOnConflictUpdateAll("id"))
+				require.NoError(t, err)
+
+				task, err := sqlutil.QueryOne[Task](ctx, tc.db, `SELECT id, title, url, is_completed FROM task WHERE id = 2`)
+				require.NoError(t, err)
+				require.Equal(t, "task2 updated", task.Title)
+				require.Equal(t, "https://m0t0k1ch1.com/task/2/updated", task.URL.String())
+			})
+
+			t.Run("success: do nothing", func(t *testing.T) {
+				ctx := t.Context()
+
+				n, err := sqlutil.BulkInsert(ctx, tc.db, tc.dialect, table, columns, [][]any{
+					{1, "task1 ignored", "http://m0t0k1ch1.com/task/1/ignored"},
+					{7, "task7", "http://m0t0k1ch1.com/task/7"},
+				}, sqlutil.WithOnConflictDoNothing("id"))
+				require.NoError(t, err)
+				require.Equal(t, int64(1), n)
+
+				title, err := sqlutil.QueryScalar[string](ctx, tc.db, `SELECT title FROM task WHERE id = 1`)
+				require.NoError(t, err)
+				require.Equal(t, "task1 updated", title)
+
+				require.Equal(t, 7, countAllTasks(t, ctx, tc.db))
+			})
+
+			t.Run("success: structs over the placeholder limit", func(t *testing.T) {
+				ctx := t.Context()
+
+				// 3 columns per row, so more than 65535 / 3 rows require multiple statements
+				tasks := make([]*bulkTestTask, 30000)
+				for i := range tasks {
+					id := 1000 + i
+					tasks[i] = &bulkTestTask{
+						ID:    id,
+						Title: fmt.Sprintf("task%d", id),
+						URL:   sqlutil.MustNewHTTPURLFromString(fmt.Sprintf("http://m0t0k1ch1.com/task/%d", id)),
+					}
+				}
+
+				n, err := sqlutil.BulkInsertStructs(ctx, tc.db, tc.dialect, table, tasks, sqlutil.WithTransaction())
+				require.NoError(t, err)
+				require.Equal(t, int64(len(tasks)), n)
+
+				require.Equal(t, 7+len(tasks), countAllTasks(t, ctx, tc.db))
+			})
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// Command stringtypegen generates a validated string column type
+// with the same surface as sqlutil.HTTPURL, together with a table-driven test file.
+//
+// Usage:
+//
+//	//go:generate go run github.com/m0t0k1ch1-go/sqlutil/v3/cmd/stringtypegen -type Slug -regex ^[a-z0-9-]+$ -maxlen 64
+//
+// The generated type is validated by -maxlen, -regex and -validate (a func(string) error in the same package),
+// in that order. At least one of them is required.
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"quote": func(s string) string {
+		return fmt.Sprintf("%q", s)
+	},
+}).ParseFS(templateFS, "templates/*.tmpl"))
+
+type stringList []string
+
+func (sl *stringList) String() string {
+	return strings.Join(*sl, ",")
+}
+
+func (sl *stringList) Set(s string) error {
+	*sl = append(*sl, s)
+
+	return nil
+}
+
+type config struct {
+	TypeName   string
+	Package    string
+	ImportPath string
+	Validate   string
+	Regex      string
+	MaxLen     int
+	Valid      []string
+	Invalid    []string
+	NoTest     bool
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "stringtypegen:", err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	var (
+		conf   config
+		output string
+		valid  stringList
+		inval  stringList
+	)
+
+	fs := flag.NewFlagSet("stringtypegen", flag.ContinueOnError)
+	fs.StringVar(&conf.TypeName, "type", "", "type name (required)")
+	fs.StringVar(&conf.Package, "package", os.Getenv("GOPACKAGE"), "package name (default $GOPACKAGE)")
+	fs.StringVar(&conf.ImportPath, "import", "", "import path of the package, used by the test file (default from go list)")
+	fs.StringVar(&conf.Validate, "validate", "", "name of a func(string) error in the same package")
+	fs.StringVar(&conf.Regex, "regex", "", "regular expression the value must match")
+	fs.IntVar(&conf.MaxLen, "maxlen", 0, "maximum length in characters (0 means no limit)")
+	fs.Var(&valid, "valid", "valid sample for the test file (repeatable)")
+	fs.Var(&inval, "invalid", "invalid sample for the test file (repeatable)")
+	fs.BoolVar(&conf.NoTest, "notest", false, "do not generate the test file")
+	fs.StringVar(&output, "output", "", "output file name (default <type>_gen.go)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	conf.Valid = valid
+	conf.Invalid = inval
+
+	if output == "" {
+		output = strings.ToLower(conf.TypeName) + "_gen.go"
+	}
+	if !conf.NoTest && conf.ImportPath == "" {
+		importPath, err := goListImportPath(filepath.Dir(output))
+		if err != nil {
+			return err
+		}
+
+		conf.ImportPath = importPath
+	}
+
+	src, testSrc, err := generate(conf)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if testSrc != nil {
+		if err := os.WriteFile(strings.TrimSuffix(output, ".go")+"_test.go", testSrc, 0o644); err != nil {
+			return fmt.Errorf("failed to write test file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func generate(conf config) (src []byte, testSrc []byte, err error) {
+	if err := conf.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if src, err = execute("type.go.tmpl", conf); err != nil {
+		return nil, nil, err
+	}
+
+	if conf.NoTest {
+		return src, nil, nil
+	}
+
+	if testSrc, err = execute("type_test.go.tmpl", conf); err != nil {
+		return nil, nil, err
+	}
+
+	return src, testSrc, nil
+}
+
+func (conf config) validate() error {
+	if !token.IsIdentifier(conf.TypeName) || !token.IsExported(conf.TypeName) {
+		return fmt.Errorf("invalid type name: must be an exported identifier: %q", conf.TypeName)
+	}
+	if !token.IsIdentifier(conf.Package) {
+		return fmt.Errorf("invalid package name: %q", conf.Package)
+	}
+	if conf.Validate != "" && !token.IsIdentifier(conf.Validate) {
+		return fmt.Errorf("invalid validate func name: %q", conf.Validate)
+	}
+	if conf.Regex != "" {
+		if _, err := regexp.Compile(conf.Regex); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+	}
+	if conf.MaxLen < 0 {
+		return errors.New("invalid maxlen: must be non-negative")
+	}
+	if conf.Validate == "" && conf.Regex == "" && conf.MaxLen == 0 {
+		return errors.New("at least one of -validate, -regex or -maxlen is required")
+	}
+	if !conf.NoTest && conf.ImportPath == "" {
+		return errors.New("import path is required to generate the test file")
+	}
+	if !conf.NoTest && len(conf.Valid) == 0 {
+		return errors.New("at least one -valid sample is required to generate the test file")
+	}
+
+	return nil
+}
+
+// Receiver returns the receiver name (the lowercased initials of the type name),
+// avoiding the names of parameters and locals in the generated methods.
+func (conf config) Receiver() string {
+	var sb strings.Builder
+	for _, r := range conf.TypeName {
+		if unicode.IsUpper(r) {
+			sb.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	switch recv := sb.String(); recv {
+	case "b", "err", "s", "src", "v":
+		if lower := strings.ToLower(conf.TypeName); token.Lookup(lower) == token.IDENT && lower != recv {
+			return lower
+		}
+
+		return "x"
+	default:
+		return recv
+	}
+}
+
+// Label returns the label used in error messages (e.g. "country code" for CountryCode).
+func (conf config) Label() string {
+	var sb strings.Builder
+	for i, r := range conf.TypeName {
+		if unicode.IsUpper(r) {
+			if i > 0 && !unicode.IsUpper(rune(conf.TypeName[i-1])) {
+				sb.WriteByte(' ')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// PatternVar returns the name of the package-level regexp variable.
+func (conf config) PatternVar() string {
+	name := conf.TypeName
+
+	n := 0
+	for n < len(name) && unicode.IsUpper(rune(name[n])) {
+		n++
+	}
+	if n > 1 && n < len(name) {
+		n--
+	}
+
+	return strings.ToLower(name[:n]) + name[n:] + "Pattern"
+}
+
+// OverMaxLen returns a sample that exceeds MaxLen.
+func (conf config) OverMaxLen() string {
+	return strings.Repeat("a", conf.MaxLen+1)
+}
+
+func execute(name string, conf config) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, conf); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %s: %w", name, err)
+	}
+
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format source: %s: %w", name, err)
+	}
+
+	return b, nil
+}
+
+func goListImportPath(dir string) (string, error) {
+	cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}", ".")
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get import path: %w", err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
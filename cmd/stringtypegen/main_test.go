@@ -0,0 +1,181 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   config
+			want string
+		}{
+			{
+				"invalid type name: unexported",
+				config{
+					TypeName: "slug",
+					Package:  "model",
+					MaxLen:   64,
+					NoTest:   true,
+				},
+				"invalid type name: must be an exported identifier",
+			},
+			{
+				"invalid package name",
+				config{
+					TypeName: "Slug",
+					MaxLen:   64,
+					NoTest:   true,
+				},
+				"invalid package name",
+			},
+			{
+				"invalid regex",
+				config{
+					TypeName: "Slug",
+					Package:  "model",
+					Regex:    "[a-z",
+					NoTest:   true,
+				},
+				"invalid regex",
+			},
+			{
+				"no validation",
+				config{
+					TypeName: "Slug",
+					Package:  "model",
+					NoTest:   true,
+				},
+				"at least one of -validate, -regex or -maxlen is required",
+			},
+			{
+				"no valid samples",
+				config{
+					TypeName:   "Slug",
+					Package:    "model",
+					ImportPath: "example.com/model",
+					MaxLen:     64,
+				},
+				"at least one -valid sample is required to generate the test file",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, _, err := generate(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		src, testSrc, err := generate(config{
+			TypeName:   "CountryCode",
+			Package:    "model",
+			ImportPath: "example.com/model",
+			Validate:   "validateCountryCode",
+			Regex:      "^[A-Z]{2}$",
+			MaxLen:     2,
+			Valid:      []string{"JP", "US"},
+			Invalid:    []string{"jp", "J"},
+		})
+		require.NoError(t, err)
+
+		requireGolden(t, "countrycode_gen.go.golden", src)
+		requireGolden(t, "countrycode_gen_test.go.golden", testSrc)
+	})
+
+	t.Run("success: no test", func(t *testing.T) {
+		src, testSrc, err := generate(config{
+			TypeName: "SKU",
+			Package:  "model",
+			MaxLen:   32,
+			NoTest:   true,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, src)
+		require.Nil(t, testSrc)
+	})
+}
+
+func TestConfig_Receiver(t *testing.T) {
+	tcs := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			"initials",
+			"CountryCode",
+			"cc",
+		},
+		{
+			"acronym",
+			"SKU",
+			"sku",
+		},
+		{
+			"conflict",
+			"Slug",
+			"slug",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, config{TypeName: tc.in}.Receiver())
+		})
+	}
+}
+
+func TestConfig_PatternVar(t *testing.T) {
+	tcs := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			"camel",
+			"CountryCode",
+			"countryCodePattern",
+		},
+		{
+			"acronym",
+			"SKU",
+			"skuPattern",
+		},
+		{
+			"acronym prefix",
+			"URLSlug",
+			"urlSlugPattern",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, config{TypeName: tc.in}.PatternVar())
+		})
+	}
+}
+
+func requireGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got))
+}
@@ -0,0 +1,271 @@
+package sqlutil
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CopyFrom inserts the rows yielded by seq into the table,
+// which must be a Fragment returned by Dialect.Identifier,
+// and returns the number of rows inserted.
+// Each row must have one value per column.
+//
+// On PostgreSQL, the rows are streamed by `COPY FROM STDIN`
+// over the pgx connection underlying c, so the driver must be github.com/jackc/pgx/v5/stdlib.
+// On other dialects, they are inserted in batches as in BulkInsert.
+//
+// If c is a *sql.DB, a connection is taken from the pool for the call.
+// To copy within a transaction, begin it on a *sql.Conn and pass the same *sql.Conn:
+//
+//	err := Transact(ctx, conn, func(ctx context.Context, tx *sql.Tx) error {
+//		_, err := CopyFrom(ctx, conn, dialect, table, columns, seq)
+//		return err
+//	})
+func CopyFrom[C *sql.DB | *sql.Conn](ctx context.Context, c C, dialect Dialect, table Fragment, columns []string, seq iter.Seq2[[]any, error]) (int64, error) {
+	if err := validateCopy(dialect, table, columns); err != nil {
+		return 0, err
+	}
+
+	return withConn(ctx, c, func(conn *sql.Conn) (int64, error) {
+		if dialect != DialectPostgreSQL {
+			return insertSeq(ctx, conn, dialect, table, columns, seq)
+		}
+
+		var n int64
+		if err := conn.Raw(func(driverConn any) error {
+			pgxConn, err := pgxConnOf(driverConn)
+			if err != nil {
+				return err
+			}
+
+			next, stop := iter.Pull2(seq)
+			defer stop()
+
+			if n, err = pgxConn.CopyFrom(ctx, pgx.Identifier(table.names), columns, &copyFromSeq{
+				columns: len(columns),
+				next:    next,
+			}); err != nil {
+				return fmt.Errorf("failed to copy rows: %w", err)
+			}
+
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+
+		return n, nil
+	})
+}
+
+// CopyFromCSV is like CopyFrom, but inserts the rows of CSV data read from r, without a header.
+// On PostgreSQL, an unquoted empty field is NULL as in `COPY FROM STDIN WITH (FORMAT csv)`;
+// on other dialects, an empty field is NULL whether quoted or not,
+// and the other fields are inserted as strings.
+func CopyFromCSV[C *sql.DB | *sql.Conn](ctx context.Context, c C, dialect Dialect, table Fragment, columns []string, r io.Reader) (int64, error) {
+	if err := validateCopy(dialect, table, columns); err != nil {
+		return 0, err
+	}
+
+	return withConn(ctx, c, func(conn *sql.Conn) (int64, error) {
+		if dialect != DialectPostgreSQL {
+			return insertSeq(ctx, conn, dialect, table, columns, csvSeq(r))
+		}
+
+		var sb strings.Builder
+		sb.WriteString("COPY ")
+		sb.WriteString(table.String())
+		sb.WriteString(" (")
+		for i, col := range columns {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(dialect.QuoteIdentifier(col))
+		}
+		sb.WriteString(") FROM STDIN WITH (FORMAT csv)")
+
+		var n int64
+		if err := conn.Raw(func(driverConn any) error {
+			pgxConn, err := pgxConnOf(driverConn)
+			if err != nil {
+				return err
+			}
+
+			tag, err := pgxConn.PgConn().CopyFrom(ctx, r, sb.String())
+			if err != nil {
+				return fmt.Errorf("failed to copy rows: %w", err)
+			}
+
+			n = tag.RowsAffected()
+
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+
+		return n, nil
+	})
+}
+
+func validateCopy(dialect Dialect, table Fragment, columns []string) error {
+	if err := dialect.validate(); err != nil {
+		return err
+	}
+	if table.names == nil {
+		return fmt.Errorf("invalid table: not an identifier: %s", table)
+	}
+	if len(columns) == 0 {
+		return errors.New("invalid columns: empty")
+	}
+
+	return nil
+}
+
+// withConn calls f with c itself or a connection taken from c.
+func withConn[C *sql.DB | *sql.Conn](ctx context.Context, c C, f func(*sql.Conn) (int64, error)) (int64, error) {
+	switch c := any(c).(type) {
+	case *sql.Conn:
+		return f(c)
+	case *sql.DB:
+		conn, err := c.Conn(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get connection: %w", err)
+		}
+		defer conn.Close()
+
+		return f(conn)
+	default:
+		panic("unreachable")
+	}
+}
+
+func pgxConnOf(driverConn any) (*pgx.Conn, error) {
+	c, ok := driverConn.(interface{ Conn() *pgx.Conn })
+	if !ok {
+		return nil, fmt.Errorf("unsupported driver connection: %T", driverConn)
+	}
+
+	return c.Conn(), nil
+}
+
+// insertSeq inserts the rows yielded by seq in batches as large as the placeholder limit allows.
+func insertSeq(ctx context.Context, conn *sql.Conn, dialect Dialect, table Fragment, columns []string, seq iter.Seq2[[]any, error]) (int64, error) {
+	batchSize := dialect.MaxPlaceholders() / len(columns)
+	if batchSize == 0 {
+		return 0, fmt.Errorf("invalid columns: %d columns exceed the placeholder limit", len(columns))
+	}
+
+	var (
+		total int64
+		batch [][]any
+	)
+	flush := func() error {
+		n, err := bulkInsert(ctx, conn, dialect, table, columns, batch)
+		total += n
+		batch = batch[:0]
+
+		return err
+	}
+
+	i := 0
+	for row, err := range seq {
+		if err != nil {
+			return total, fmt.Errorf("failed to read rows: %w", err)
+		}
+		if len(row) != len(columns) {
+			return total, fmt.Errorf("invalid row: index %d: %d values for %d columns", i, len(row), len(columns))
+		}
+		i++
+
+		batch = append(batch, row)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return total, err
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// copyFromSeq adapts a pulled iter.Seq2 to pgx.CopyFromSource.
+type copyFromSeq struct {
+	columns int
+	next    func() ([]any, error, bool)
+	index   int
+	row     []any
+	err     error
+}
+
+func (src *copyFromSeq) Next() bool {
+	row, err, ok := src.next()
+	if !ok {
+		return false
+	}
+	if err != nil {
+		src.err = fmt.Errorf("failed to read rows: %w", err)
+
+		return false
+	}
+	if len(row) != src.columns {
+		src.err = fmt.Errorf("invalid row: index %d: %d values for %d columns", src.index, len(row), src.columns)
+
+		return false
+	}
+
+	src.index++
+	src.row = row
+
+	return true
+}
+
+func (src *copyFromSeq) Values() ([]any, error) {
+	return src.row, nil
+}
+
+func (src *copyFromSeq) Err() error {
+	return src.err
+}
+
+// csvSeq yields the records of CSV data read from r, with empty fields as nil.
+func csvSeq(r io.Reader) iter.Seq2[[]any, error] {
+	return func(yield func([]any, error) bool) {
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+
+		for {
+			record, err := cr.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, fmt.Errorf("invalid csv: %w", err))
+
+				return
+			}
+
+			row := make([]any, len(record))
+			for i, field := range record {
+				if field != "" {
+					row[i] = field
+				}
+			}
+
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestCopyFrom(t *testing.T) {
+	tcs := []struct {
+		name    string
+		db      *sql.DB
+		dialect sqlutil.Dialect
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			sqlutil.DialectMySQL,
+		},
+		{
+			"postgresql",
+			psqlDB,
+			sqlutil.DialectPostgreSQL,
+		},
+	}
+
+	columns := []string{"id", "title", "url"}
+
+	seqOf := func(rows ...[]any) func(yield func([]any, error) bool) {
+		return func(yield func([]any, error) bool) {
+			for _, row := range rows {
+				if !yield(row, nil) {
+					return
+				}
+			}
+		}
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			table := tc.dialect.Identifier("task")
+
+			t.Run("failure: not an identifier", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.CopyFrom(ctx, tc.db, tc.dialect, sqlutil.NewFragment("task"), columns, seqOf())
+				require.ErrorContains(t, err, "invalid table: not an identifier: task")
+			})
+
+			t.Run("failure: invalid row", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.CopyFrom(ctx, tc.db, tc.dialect, table, columns, seqOf(
+					[]any{3, "task3"},
+				))
+				require.ErrorContains(t, err, "invalid row: index 0: 2 values for 3 columns")
+
+				require.Equal(t, 2, countAllTasks(t, ctx, tc.db))
+			})
+
+			t.Run("failure: error from iterator", func(t *testing.T) {
+				ctx := t.Context()
+
+				errSomethingWentWrong := errors.New("something went wrong")
+
+				_, err := sqlutil.CopyFrom(ctx, tc.db, tc.dialect, table, columns, func(yield func([]any, error) bool) {
+					yield(nil, errSomethingWentWrong)
+				})
+				require.ErrorIs(t, err, errSomethingWentWrong)
+			})
+
+			t.Run("failure: rollback within transaction", func(t *testing.T) {
+				ctx := t.Context()
+
+				conn, err := tc.db.Conn(ctx)
+				require.NoError(t, err)
+				defer conn.Close()
+
+				errSomethingWentWrong := errors.New("something went wrong")
+
+				err = sqlutil.Transact(ctx, conn, func(ctx context.Context, tx *sql.Tx) error {
+					n, err := sqlutil.CopyFrom(ctx, conn, tc.dialect, table, columns, seqOf(
+						[]any{3, "task3", "http://m0t0k1ch1.com/task/3"},
+					))
+					require.NoError(t, err)
+					require.Equal(t, int64(1), n)
+
+					return errSomethingWentWrong
+				})
+				require.ErrorIs(t, err, errSomethingWentWrong)
+
+				require.Equal(t, 2, countAllTasks(t, ctx, tc.db))
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				n, err := sqlutil.CopyFrom(ctx, tc.db, tc.dialect, table, columns, seqOf(
+					[]any{3, "task3", sqlutil.MustNewHTTPURLFromString("http://m0t0k1ch1.com/task/3")},
+					[]any{4, "task4", "http://m0t0k1ch1.com/task/4"},
+				))
+				require.NoError(t, err)
+				require.Equal(t, int64(2), n)
+
+				task, err := sqlutil.QueryOne[Task](ctx, tc.db, `SELECT id, title, url, is_completed FROM task WHERE id = 3`)
+				require.NoError(t, err)
+				require.Equal(t, "http://m0t0k1ch1.com/task/3", task.URL.String())
+
+				require.Equal(t, 4, countAllTasks(t, ctx, tc.db))
+			})
+
+			t.Run("success: csv", func(t *testing.T) {
+				ctx := t.Context()
+
+				n, err := sqlutil.CopyFromCSV(ctx, tc.db, tc.dialect, table, columns, strings.NewReader(
+					"5,task5,http://m0t0k1ch1.com/task/5\n"+
+						"6,\"task6, with comma\",http://m0t0k1ch1.com/task/6\n",
+				))
+				require.NoError(t, err)
+				require.Equal(t, int64(2), n)
+
+				title, err := sqlutil.QueryScalar[string](ctx, tc.db, `SELECT title FROM task WHERE id = 6`)
+				require.NoError(t, err)
+				require.Equal(t, "task6, with comma", title)
+
+				require.Equal(t, 6, countAllTasks(t, ctx, tc.db))
+			})
+		})
+	}
+}
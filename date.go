@@ -0,0 +1,178 @@
+package sqlutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const dateLayout = time.DateOnly
+
+// Date represents a calendar date with no time component.
+type Date struct {
+	t time.Time
+}
+
+// NewDate returns a new Date.
+// Out-of-range values are normalized in the same way as time.Date.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{
+		t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// NewDateFromTime returns a new Date from the calendar date of t in its own location.
+func NewDateFromTime(t time.Time) Date {
+	year, month, day := t.Date()
+
+	return NewDate(year, month, day)
+}
+
+// NewDateFromString returns a new Date from a string in YYYY-MM-DD format.
+func NewDateFromString(s string) (Date, error) {
+	var d Date
+	if err := d.setString(s); err != nil {
+		return Date{}, err
+	}
+
+	return d, nil
+}
+
+// MustNewDateFromString panics if the input is invalid.
+func MustNewDateFromString(s string) Date {
+	d, err := NewDateFromString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func (d *Date) setString(s string) error {
+	if len(s) == 0 {
+		return errors.New("invalid date string: empty")
+	}
+
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return fmt.Errorf("invalid date string: %w", err)
+	}
+
+	*d = NewDateFromTime(t)
+
+	return nil
+}
+
+// Year returns the year.
+func (d Date) Year() int {
+	return d.t.Year()
+}
+
+// Month returns the month.
+func (d Date) Month() time.Month {
+	return d.t.Month()
+}
+
+// Day returns the day of the month.
+func (d Date) Day() int {
+	return d.t.Day()
+}
+
+// Time returns the date as a time.Time at midnight in the given location.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.t.Year(), d.t.Month(), d.t.Day(), 0, 0, 0, 0, loc)
+}
+
+// IsZero reports whether the value is the zero date (0001-01-01).
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// String implements fmt.Stringer.
+// It returns the value in YYYY-MM-DD format.
+func (d Date) String() string {
+	return d.t.Format(dateLayout)
+}
+
+// Value implements driver.Valuer.
+// It returns the value as a string in YYYY-MM-DD format,
+// which avoids any time zone conversion by the driver.
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner.
+// It accepts a time.Time, string or []byte.
+// For a time.Time, the calendar date in its own location is used.
+func (d *Date) Scan(src any) error {
+	if src == nil {
+		return errors.New("invalid source: nil")
+	}
+
+	var s string
+	{
+		switch v := src.(type) {
+		case time.Time:
+			*d = NewDateFromTime(v)
+
+			return nil
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return fmt.Errorf("unsupported source type: %T", src)
+		}
+	}
+
+	if err := d.setString(s); err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the value in YYYY-MM-DD format.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(b []byte) error {
+	if err := d.setString(string(b)); err != nil {
+		return fmt.Errorf("invalid text: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns the value as a JSON string in YYYY-MM-DD format.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a JSON string.
+func (d *Date) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 {
+		return errors.New("invalid json value: empty")
+	}
+	if string(b) == "null" {
+		return errors.New("invalid json value: null")
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	if err := d.setString(s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestDate(t *testing.T) {
+	var d sqlutil.Date
+	require.Implements(t, (*fmt.Stringer)(nil), &d)
+	require.Implements(t, (*driver.Valuer)(nil), &d)
+	require.Implements(t, (*sql.Scanner)(nil), &d)
+	require.Implements(t, (*encoding.TextMarshaler)(nil), &d)
+	require.Implements(t, (*encoding.TextUnmarshaler)(nil), &d)
+	require.Implements(t, (*json.Marshaler)(nil), &d)
+	require.Implements(t, (*json.Unmarshaler)(nil), &d)
+}
+
+func TestNewDate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   [3]int
+			want string
+		}{
+			{
+				"normal",
+				[3]int{2025, 1, 2},
+				"2025-01-02",
+			},
+			{
+				"normalized",
+				[3]int{2025, 2, 30},
+				"2025-03-02",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				d := sqlutil.NewDate(tc.in[0], time.Month(tc.in[1]), tc.in[2])
+				require.Equal(t, tc.want, d.String())
+			})
+		}
+	})
+}
+
+func TestNewDateFromTime(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	d := sqlutil.NewDateFromTime(time.Date(2025, 1, 2, 1, 0, 0, 0, jst))
+	require.Equal(t, "2025-01-02", d.String())
+	require.Equal(t, 2025, d.Year())
+	require.Equal(t, time.January, d.Month())
+	require.Equal(t, 2, d.Day())
+	require.Equal(t, time.Date(2025, 1, 2, 0, 0, 0, 0, jst), d.Time(jst))
+}
+
+func TestNewDateFromString(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"empty",
+				"",
+				"invalid date string: empty",
+			},
+			{
+				"datetime",
+				"2025-01-02 03:04:05",
+				"invalid date string",
+			},
+			{
+				"mysql zero date",
+				"0000-00-00",
+				"invalid date string",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.NewDateFromString(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		d, err := sqlutil.NewDateFromString("2025-01-02")
+		require.NoError(t, err)
+		require.Equal(t, "2025-01-02", d.String())
+	})
+}
+
+func TestDate_Value(t *testing.T) {
+	v, err := sqlutil.MustNewDateFromString("2025-01-02").Value()
+	require.NoError(t, err)
+	require.Equal(t, "2025-01-02", v)
+}
+
+func TestDate_Scan(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"nil",
+				nil,
+				"invalid source: nil",
+			},
+			{
+				"int64",
+				int64(1),
+				"unsupported source type: int64",
+			},
+			{
+				"string: empty",
+				"",
+				"invalid source: invalid date string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var d sqlutil.Date
+				err := d.Scan(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"time.Time: utc",
+				time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+				"2025-01-02",
+			},
+			{
+				"time.Time: local",
+				time.Date(2025, 1, 2, 0, 0, 0, 0, time.FixedZone("JST", 9*60*60)),
+				"2025-01-02",
+			},
+			{
+				"string",
+				"2025-01-02",
+				"2025-01-02",
+			},
+			{
+				"[]byte",
+				[]byte("2025-01-02"),
+				"2025-01-02",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var d sqlutil.Date
+				err := d.Scan(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, d.String())
+			})
+		}
+	})
+}
+
+func TestDate_MarshalText(t *testing.T) {
+	b, err := sqlutil.MustNewDateFromString("2025-01-02").MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, []byte("2025-01-02"), b)
+}
+
+func TestDate_UnmarshalText(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		var d sqlutil.Date
+		err := d.UnmarshalText([]byte("2025/01/02"))
+		require.ErrorContains(t, err, "invalid text: invalid date string")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var d sqlutil.Date
+		err := d.UnmarshalText([]byte("2025-01-02"))
+		require.NoError(t, err)
+		require.Equal(t, "2025-01-02", d.String())
+	})
+}
+
+func TestDate_MarshalJSON(t *testing.T) {
+	b, err := sqlutil.MustNewDateFromString("2025-01-02").MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`"2025-01-02"`), b)
+}
+
+func TestDate_UnmarshalJSON(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   []byte
+			want string
+		}{
+			{
+				"empty",
+				[]byte{},
+				"invalid json value: empty",
+			},
+			{
+				"null",
+				[]byte(`null`),
+				"invalid json value: null",
+			},
+			{
+				"string: empty",
+				[]byte(`""`),
+				"invalid json string: invalid date string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var d sqlutil.Date
+				err := d.UnmarshalJSON(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var d sqlutil.Date
+		err := d.UnmarshalJSON([]byte(`"2025-01-02"`))
+		require.NoError(t, err)
+		require.Equal(t, "2025-01-02", d.String())
+	})
+}
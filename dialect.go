@@ -0,0 +1,218 @@
+package sqlutil
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Dialect represents a SQL dialect.
+type Dialect int
+
+const (
+	// DialectMySQL represents MySQL.
+	DialectMySQL Dialect = iota + 1
+	// DialectPostgreSQL represents PostgreSQL.
+	DialectPostgreSQL
+	// DialectSQLite represents SQLite.
+	DialectSQLite
+)
+
+// driverDialects maps the package paths of known drivers to their dialects.
+var driverDialects = []struct {
+	pkgPath string
+	dialect Dialect
+}{
+	{"github.com/go-sql-driver/mysql", DialectMySQL},
+	{"github.com/jackc/pgx/", DialectPostgreSQL},
+	{"github.com/lib/pq", DialectPostgreSQL},
+	{"github.com/mattn/go-sqlite3", DialectSQLite},
+	{"modernc.org/sqlite", DialectSQLite},
+	{"github.com/ncruces/go-sqlite3", DialectSQLite},
+}
+
+// DetectDialect returns the dialect of the database by the type of its driver.
+func DetectDialect(db *sql.DB) (Dialect, error) {
+	typ := reflect.TypeOf(db.Driver())
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	for _, dd := range driverDialects {
+		if strings.HasPrefix(typ.PkgPath(), dd.pkgPath) {
+			return dd.dialect, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported driver: %s", typ)
+}
+
+// String implements fmt.Stringer.
+func (d Dialect) String() string {
+	switch d {
+	case DialectMySQL:
+		return "mysql"
+	case DialectPostgreSQL:
+		return "postgresql"
+	case DialectSQLite:
+		return "sqlite"
+	default:
+		return fmt.Sprintf("Dialect(%d)", int(d))
+	}
+}
+
+func (d Dialect) validate() error {
+	switch d {
+	case DialectMySQL, DialectPostgreSQL, DialectSQLite:
+		return nil
+	default:
+		return fmt.Errorf("unsupported dialect: %s", d)
+	}
+}
+
+// Placeholder returns the n-th (1-based) bind parameter placeholder:
+// $n for PostgreSQL and ? otherwise.
+func (d Dialect) Placeholder(n int) string {
+	if d == DialectPostgreSQL {
+		return "$" + strconv.Itoa(n)
+	}
+
+	return "?"
+}
+
+// MaxPlaceholders returns the maximum number of bind parameters in a single statement:
+// 65535 for MySQL and PostgreSQL, and 32766 for SQLite (3.32.0 or later).
+func (d Dialect) MaxPlaceholders() int {
+	if d == DialectSQLite {
+		return 32766
+	}
+
+	return 65535
+}
+
+// QuoteIdentifier quotes an identifier such as a table or column name:
+// with backticks for MySQL and double quotes otherwise, doubling embedded quotes.
+func (d Dialect) QuoteIdentifier(name string) string {
+	q := `"`
+	if d == DialectMySQL {
+		q = "`"
+	}
+
+	return q + strings.ReplaceAll(name, q, q+q) + q
+}
+
+// QuoteQualifiedIdentifier quotes each part of a qualified identifier such as schema.table
+// and joins them with dots.
+func (d Dialect) QuoteQualifiedIdentifier(names ...string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.QuoteIdentifier(name)
+	}
+
+	return strings.Join(quoted, ".")
+}
+
+// SavepointSQL returns the statement that establishes a savepoint.
+func (d Dialect) SavepointSQL(name string) string {
+	return "SAVEPOINT " + d.QuoteIdentifier(name)
+}
+
+// ReleaseSavepointSQL returns the statement that releases a savepoint.
+func (d Dialect) ReleaseSavepointSQL(name string) string {
+	return "RELEASE SAVEPOINT " + d.QuoteIdentifier(name)
+}
+
+// RollbackToSavepointSQL returns the statement that rolls back to a savepoint.
+func (d Dialect) RollbackToSavepointSQL(name string) string {
+	return "ROLLBACK TO SAVEPOINT " + d.QuoteIdentifier(name)
+}
+
+// UpsertSQL returns the clause appended to an INSERT statement
+// to update updateColumns with the inserted values when a row conflicts on conflictColumns,
+// or to do nothing if updateColumns is empty.
+//
+// MySQL ignores conflictColumns in favor of any unique key,
+// but requires at least one of them to do nothing.
+func (d Dialect) UpsertSQL(conflictColumns, updateColumns []string) (string, error) {
+	if err := d.validate(); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	if d == DialectMySQL {
+		sb.WriteString("ON DUPLICATE KEY UPDATE ")
+
+		if len(updateColumns) == 0 {
+			if len(conflictColumns) == 0 {
+				return "", errors.New("invalid upsert: conflict columns are required to do nothing")
+			}
+
+			col := d.QuoteIdentifier(conflictColumns[0])
+			sb.WriteString(col + " = " + col)
+
+			return sb.String(), nil
+		}
+
+		for i, col := range updateColumns {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+
+			col = d.QuoteIdentifier(col)
+			sb.WriteString(col + " = VALUES(" + col + ")")
+		}
+
+		return sb.String(), nil
+	}
+
+	if len(conflictColumns) == 0 {
+		return "", errors.New("invalid upsert: conflict columns are required")
+	}
+
+	sb.WriteString("ON CONFLICT (")
+	for i, col := range conflictColumns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(d.QuoteIdentifier(col))
+	}
+	sb.WriteString(") ")
+
+	if len(updateColumns) == 0 {
+		sb.WriteString("DO NOTHING")
+
+		return sb.String(), nil
+	}
+
+	sb.WriteString("DO UPDATE SET ")
+	for i, col := range updateColumns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		col = d.QuoteIdentifier(col)
+		sb.WriteString(col + " = EXCLUDED." + col)
+	}
+
+	return sb.String(), nil
+}
+
+// AdvisoryLockSQL returns the queries that acquire and release a session-level advisory lock
+// named by their only argument.
+// The lock query waits until the lock is acquired;
+// on MySQL, it returns 1 on success.
+// SQLite does not support advisory locks.
+func (d Dialect) AdvisoryLockSQL() (lock string, unlock string, err error) {
+	switch d {
+	case DialectMySQL:
+		return "SELECT GET_LOCK(?, -1)", "SELECT RELEASE_LOCK(?)", nil
+	case DialectPostgreSQL:
+		return "SELECT pg_advisory_lock(hashtext($1))", "SELECT pg_advisory_unlock(hashtext($1))", nil
+	default:
+		return "", "", fmt.Errorf("unsupported dialect: advisory lock: %s", d)
+	}
+}
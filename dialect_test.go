@@ -0,0 +1,321 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type dialectTestConnector struct{}
+
+func (dialectTestConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (dialectTestConnector) Driver() driver.Driver {
+	return dialectTestDriver{}
+}
+
+type dialectTestDriver struct{}
+
+func (dialectTestDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestDetectDialect(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		db := sql.OpenDB(dialectTestConnector{})
+		defer db.Close()
+
+		_, err := sqlutil.DetectDialect(db)
+		require.ErrorContains(t, err, "unsupported driver: sqlutil_test.dialectTestDriver")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name       string
+			driverName string
+			dsn        string
+			want       sqlutil.Dialect
+		}{
+			{
+				"mysql",
+				"mysql",
+				"root@tcp(localhost:3306)/sqlutil",
+				sqlutil.DialectMySQL,
+			},
+			{
+				"postgresql",
+				"pgx",
+				"postgres://localhost:5432/sqlutil",
+				sqlutil.DialectPostgreSQL,
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				db, err := sql.Open(tc.driverName, tc.dsn)
+				require.NoError(t, err)
+				defer db.Close()
+
+				dialect, err := sqlutil.DetectDialect(db)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, dialect)
+			})
+		}
+	})
+}
+
+func TestDialect_String(t *testing.T) {
+	tcs := []struct {
+		name string
+		in   sqlutil.Dialect
+		want string
+	}{
+		{
+			"mysql",
+			sqlutil.DialectMySQL,
+			"mysql",
+		},
+		{
+			"postgresql",
+			sqlutil.DialectPostgreSQL,
+			"postgresql",
+		},
+		{
+			"sqlite",
+			sqlutil.DialectSQLite,
+			"sqlite",
+		},
+		{
+			"unknown",
+			0,
+			"Dialect(0)",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.in.String())
+		})
+	}
+}
+
+func TestDialect_Placeholder(t *testing.T) {
+	tcs := []struct {
+		name string
+		in   sqlutil.Dialect
+		want string
+	}{
+		{
+			"mysql",
+			sqlutil.DialectMySQL,
+			"?",
+		},
+		{
+			"postgresql",
+			sqlutil.DialectPostgreSQL,
+			"$2",
+		},
+		{
+			"sqlite",
+			sqlutil.DialectSQLite,
+			"?",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.in.Placeholder(2))
+		})
+	}
+}
+
+func TestDialect_MaxPlaceholders(t *testing.T) {
+	require.Equal(t, 65535, sqlutil.DialectMySQL.MaxPlaceholders())
+	require.Equal(t, 65535, sqlutil.DialectPostgreSQL.MaxPlaceholders())
+	require.Equal(t, 32766, sqlutil.DialectSQLite.MaxPlaceholders())
+}
+
+func TestDialect_QuoteIdentifier(t *testing.T) {
+	tcs := []struct {
+		name    string
+		dialect sqlutil.Dialect
+		in      string
+		want    string
+	}{
+		{
+			"mysql",
+			sqlutil.DialectMySQL,
+			"ta`sk",
+			"`ta``sk`",
+		},
+		{
+			"postgresql",
+			sqlutil.DialectPostgreSQL,
+			`ta"sk`,
+			`"ta""sk"`,
+		},
+		{
+			"sqlite",
+			sqlutil.DialectSQLite,
+			"task",
+			`"task"`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.dialect.QuoteIdentifier(tc.in))
+		})
+	}
+}
+
+func TestDialect_QuoteQualifiedIdentifier(t *testing.T) {
+	tcs := []struct {
+		name    string
+		dialect sqlutil.Dialect
+		in      []string
+		want    string
+	}{
+		{
+			"mysql",
+			sqlutil.DialectMySQL,
+			[]string{"sqlutil", "ta`sk"},
+			"`sqlutil`.`ta``sk`",
+		},
+		{
+			"postgresql",
+			sqlutil.DialectPostgreSQL,
+			[]string{"public", "ta.sk"},
+			`"public"."ta.sk"`,
+		},
+		{
+			"sqlite: single",
+			sqlutil.DialectSQLite,
+			[]string{"task"},
+			`"task"`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.dialect.QuoteQualifiedIdentifier(tc.in...))
+		})
+	}
+}
+
+func TestDialect_SavepointSQL(t *testing.T) {
+	d := sqlutil.DialectMySQL
+	require.Equal(t, "SAVEPOINT `sp1`", d.SavepointSQL("sp1"))
+	require.Equal(t, "RELEASE SAVEPOINT `sp1`", d.ReleaseSavepointSQL("sp1"))
+	require.Equal(t, "ROLLBACK TO SAVEPOINT `sp1`", d.RollbackToSavepointSQL("sp1"))
+}
+
+func TestDialect_UpsertSQL(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name            string
+			dialect         sqlutil.Dialect
+			conflictColumns []string
+			updateColumns   []string
+			want            string
+		}{
+			{
+				"unsupported dialect",
+				0,
+				[]string{"id"},
+				nil,
+				"unsupported dialect: Dialect(0)",
+			},
+			{
+				"mysql: do nothing without conflict columns",
+				sqlutil.DialectMySQL,
+				nil,
+				nil,
+				"invalid upsert: conflict columns are required to do nothing",
+			},
+			{
+				"postgresql: no conflict columns",
+				sqlutil.DialectPostgreSQL,
+				nil,
+				[]string{"title"},
+				"invalid upsert: conflict columns are required",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := tc.dialect.UpsertSQL(tc.conflictColumns, tc.updateColumns)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name            string
+			dialect         sqlutil.Dialect
+			conflictColumns []string
+			updateColumns   []string
+			want            string
+		}{
+			{
+				"mysql: update",
+				sqlutil.DialectMySQL,
+				[]string{"id"},
+				[]string{"title", "url"},
+				"ON DUPLICATE KEY UPDATE `title` = VALUES(`title`), `url` = VALUES(`url`)",
+			},
+			{
+				"mysql: do nothing",
+				sqlutil.DialectMySQL,
+				[]string{"id"},
+				nil,
+				"ON DUPLICATE KEY UPDATE `id` = `id`",
+			},
+			{
+				"postgresql: update",
+				sqlutil.DialectPostgreSQL,
+				[]string{"id"},
+				[]string{"title", "url"},
+				`ON CONFLICT ("id") DO UPDATE SET "title" = EXCLUDED."title", "url" = EXCLUDED."url"`,
+			},
+			{
+				"sqlite: do nothing",
+				sqlutil.DialectSQLite,
+				[]string{"id", "title"},
+				nil,
+				`ON CONFLICT ("id", "title") DO NOTHING`,
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				s, err := tc.dialect.UpsertSQL(tc.conflictColumns, tc.updateColumns)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, s)
+			})
+		}
+	})
+}
+
+func TestDialect_AdvisoryLockSQL(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		_, _, err := sqlutil.DialectSQLite.AdvisoryLockSQL()
+		require.ErrorContains(t, err, "unsupported dialect: advisory lock: sqlite")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		lock, unlock, err := sqlutil.DialectPostgreSQL.AdvisoryLockSQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT pg_advisory_lock(hashtext($1))", lock)
+		require.Equal(t, "SELECT pg_advisory_unlock(hashtext($1))", unlock)
+	})
+}
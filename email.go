@@ -0,0 +1,203 @@
+package sqlutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// EmailOption configures how NewEmail normalizes an address.
+type EmailOption func(*emailConfig)
+
+type emailConfig struct {
+	lowercaseLocalPart bool
+}
+
+// WithLowercaseLocalPart lowercases the local part.
+// RFC 5321 allows case-sensitive local parts, but most providers ignore case.
+func WithLowercaseLocalPart() EmailOption {
+	return func(conf *emailConfig) {
+		conf.lowercaseLocalPart = true
+	}
+}
+
+// Email represents an email address.
+// The domain is always converted to lowercase ASCII (IDNA).
+type Email struct {
+	localPart          string
+	domain             string
+	lowercaseLocalPart bool
+}
+
+// NewEmail returns a new Email.
+// It accepts a bare address (addr-spec) without a display name or angle brackets.
+func NewEmail(s string, opts ...EmailOption) (Email, error) {
+	var conf emailConfig
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	e := Email{
+		lowercaseLocalPart: conf.lowercaseLocalPart,
+	}
+	if err := e.setString(s); err != nil {
+		return Email{}, err
+	}
+
+	return e, nil
+}
+
+// MustNewEmail panics if the input is invalid.
+func MustNewEmail(s string, opts ...EmailOption) Email {
+	e, err := NewEmail(s, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+func (e *Email) setString(s string) error {
+	if len(s) == 0 {
+		return errors.New("invalid email string: empty")
+	}
+
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return fmt.Errorf("invalid email string: %w", err)
+	}
+	if addr.Name != "" {
+		return errors.New("invalid email string: display name not allowed")
+	}
+	if addr.Address != s {
+		return errors.New("invalid email string: must be a bare address")
+	}
+
+	i := strings.LastIndexByte(s, '@')
+
+	domain, err := idna.Lookup.ToASCII(s[i+1:])
+	if err != nil {
+		return fmt.Errorf("invalid email string: invalid domain: %w", err)
+	}
+
+	e.localPart = s[:i]
+	if e.lowercaseLocalPart {
+		e.localPart = strings.ToLower(e.localPart)
+	}
+	e.domain = strings.ToLower(domain)
+
+	return nil
+}
+
+// WithLowercaseLocalPart returns a copy of the value with the local part lowercased,
+// which also lowercases the local part of the addresses decoded into it
+// by Scan and the Unmarshal methods, e.g. to normalize a JSON request:
+//
+//	req := signupRequest{Email: sqlutil.Email{}.WithLowercaseLocalPart()}
+//	err := json.Unmarshal(b, &req)
+func (e Email) WithLowercaseLocalPart() Email {
+	e.localPart = strings.ToLower(e.localPart)
+	e.lowercaseLocalPart = true
+
+	return e
+}
+
+// LocalPart returns the local part (before the @).
+func (e Email) LocalPart() string {
+	return e.localPart
+}
+
+// Domain returns the domain (after the @) in lowercase ASCII.
+func (e Email) Domain() string {
+	return e.domain
+}
+
+// String implements fmt.Stringer.
+// It returns the value as a string.
+func (e Email) String() string {
+	if e.domain == "" {
+		return ""
+	}
+
+	return e.localPart + "@" + e.domain
+}
+
+// Value implements driver.Valuer.
+// It returns the value as a string.
+func (e Email) Value() (driver.Value, error) {
+	return e.String(), nil
+}
+
+// Scan implements sql.Scanner.
+// It accepts a string or []byte.
+func (e *Email) Scan(src any) error {
+	if src == nil {
+		return errors.New("invalid source: nil")
+	}
+
+	var s string
+	{
+		switch v := src.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return fmt.Errorf("unsupported source type: %T", src)
+		}
+	}
+
+	if err := e.setString(s); err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the value as a string.
+func (e Email) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (e *Email) UnmarshalText(b []byte) error {
+	if err := e.setString(string(b)); err != nil {
+		return fmt.Errorf("invalid text: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns the value as a JSON string.
+func (e Email) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a JSON string.
+func (e *Email) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 {
+		return errors.New("invalid json value: empty")
+	}
+	if string(b) == "null" {
+		return errors.New("invalid json value: null")
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	if err := e.setString(s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,303 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestEmail(t *testing.T) {
+	var e sqlutil.Email
+	require.Implements(t, (*fmt.Stringer)(nil), &e)
+	require.Implements(t, (*driver.Valuer)(nil), &e)
+	require.Implements(t, (*sql.Scanner)(nil), &e)
+	require.Implements(t, (*encoding.TextMarshaler)(nil), &e)
+	require.Implements(t, (*encoding.TextUnmarshaler)(nil), &e)
+	require.Implements(t, (*json.Marshaler)(nil), &e)
+	require.Implements(t, (*json.Unmarshaler)(nil), &e)
+}
+
+func TestNewEmail(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"empty",
+				"",
+				"invalid email string: empty",
+			},
+			{
+				"missing domain",
+				"m0t0k1ch1",
+				"invalid email string",
+			},
+			{
+				"display name",
+				"m0t0k1ch1 <m0t0k1ch1@example.com>",
+				"invalid email string: display name not allowed",
+			},
+			{
+				"angle brackets",
+				"<m0t0k1ch1@example.com>",
+				"invalid email string: must be a bare address",
+			},
+			{
+				"invalid domain",
+				"m0t0k1ch1@-example.com",
+				"invalid email string: invalid domain",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.NewEmail(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name          string
+			in            string
+			opts          []sqlutil.EmailOption
+			want          string
+			wantLocalPart string
+			wantDomain    string
+		}{
+			{
+				"lowercase domain",
+				"M0t0k1ch1@EXAMPLE.com",
+				nil,
+				"M0t0k1ch1@example.com",
+				"M0t0k1ch1",
+				"example.com",
+			},
+			{
+				"lowercase local part",
+				"M0t0k1ch1@EXAMPLE.com",
+				[]sqlutil.EmailOption{sqlutil.WithLowercaseLocalPart()},
+				"m0t0k1ch1@example.com",
+				"m0t0k1ch1",
+				"example.com",
+			},
+			{
+				"idna",
+				"m0t0k1ch1@ÉXAMPLE.jp",
+				nil,
+				"m0t0k1ch1@xn--xample-9ua.jp",
+				"m0t0k1ch1",
+				"xn--xample-9ua.jp",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				e, err := sqlutil.NewEmail(tc.in, tc.opts...)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, e.String())
+				require.Equal(t, tc.wantLocalPart, e.LocalPart())
+				require.Equal(t, tc.wantDomain, e.Domain())
+			})
+		}
+	})
+}
+
+func TestMustNewEmail(t *testing.T) {
+	t.Run("panic", func(t *testing.T) {
+		require.PanicsWithError(t, "invalid email string: empty", func() {
+			sqlutil.MustNewEmail("")
+		})
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e := sqlutil.MustNewEmail("m0t0k1ch1@example.com")
+		require.Equal(t, "m0t0k1ch1@example.com", e.String())
+	})
+}
+
+func TestEmail_WithLowercaseLocalPart(t *testing.T) {
+	t.Run("value", func(t *testing.T) {
+		e := sqlutil.MustNewEmail("M0t0k1ch1@Example.com").WithLowercaseLocalPart()
+		require.Equal(t, "m0t0k1ch1@example.com", e.String())
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		e := sqlutil.Email{}.WithLowercaseLocalPart()
+		require.NoError(t, e.Scan([]byte("M0t0k1ch1@example.com")))
+		require.Equal(t, "m0t0k1ch1@example.com", e.String())
+	})
+
+	t.Run("text", func(t *testing.T) {
+		e := sqlutil.Email{}.WithLowercaseLocalPart()
+		require.NoError(t, e.UnmarshalText([]byte("M0t0k1ch1@example.com")))
+		require.Equal(t, "m0t0k1ch1@example.com", e.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		req := struct {
+			Email sqlutil.Email `json:"email"`
+		}{
+			Email: sqlutil.Email{}.WithLowercaseLocalPart(),
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"email":"M0t0k1ch1@example.com"}`), &req))
+		require.Equal(t, "m0t0k1ch1@example.com", req.Email.String())
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		var e sqlutil.Email
+		require.NoError(t, e.UnmarshalText([]byte("M0t0k1ch1@example.com")))
+		require.Equal(t, "M0t0k1ch1@example.com", e.String())
+	})
+}
+
+func TestEmail_Value(t *testing.T) {
+	v, err := sqlutil.MustNewEmail("m0t0k1ch1@EXAMPLE.com").Value()
+	require.NoError(t, err)
+	require.Equal(t, "m0t0k1ch1@example.com", v)
+}
+
+func TestEmail_Scan(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"nil",
+				nil,
+				"invalid source: nil",
+			},
+			{
+				"int64",
+				int64(1),
+				"unsupported source type: int64",
+			},
+			{
+				"string: empty",
+				"",
+				"invalid source: invalid email string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var e sqlutil.Email
+				err := e.Scan(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"string",
+				"m0t0k1ch1@example.com",
+				"m0t0k1ch1@example.com",
+			},
+			{
+				"[]byte",
+				[]byte("M0t0k1ch1@Example.com"),
+				"M0t0k1ch1@example.com",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var e sqlutil.Email
+				err := e.Scan(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, e.String())
+			})
+		}
+	})
+}
+
+func TestEmail_MarshalText(t *testing.T) {
+	b, err := sqlutil.MustNewEmail("m0t0k1ch1@example.com").MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, []byte("m0t0k1ch1@example.com"), b)
+}
+
+func TestEmail_UnmarshalText(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		var e sqlutil.Email
+		err := e.UnmarshalText([]byte{})
+		require.ErrorContains(t, err, "invalid text: invalid email string: empty")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var e sqlutil.Email
+		err := e.UnmarshalText([]byte("m0t0k1ch1@example.com"))
+		require.NoError(t, err)
+		require.Equal(t, "m0t0k1ch1@example.com", e.String())
+	})
+}
+
+func TestEmail_MarshalJSON(t *testing.T) {
+	b, err := sqlutil.MustNewEmail("m0t0k1ch1@example.com").MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`"m0t0k1ch1@example.com"`), b)
+}
+
+func TestEmail_UnmarshalJSON(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   []byte
+			want string
+		}{
+			{
+				"empty",
+				[]byte{},
+				"invalid json value: empty",
+			},
+			{
+				"null",
+				[]byte(`null`),
+				"invalid json value: null",
+			},
+			{
+				"number",
+				[]byte(`1`),
+				"invalid json string",
+			},
+			{
+				"string: empty",
+				[]byte(`""`),
+				"invalid json string: invalid email string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var e sqlutil.Email
+				err := e.UnmarshalJSON(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var e sqlutil.Email
+		err := e.UnmarshalJSON([]byte(`"m0t0k1ch1@example.com"`))
+		require.NoError(t, err)
+		require.Equal(t, "m0t0k1ch1@example.com", e.String())
+	})
+}
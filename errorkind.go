@@ -0,0 +1,321 @@
+package sqlutil
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrorKind is a dialect-independent kind of database error.
+type ErrorKind int
+
+const (
+	// ErrorKindUnknown represents an error of no known kind.
+	ErrorKindUnknown ErrorKind = iota
+	// ErrorKindUniqueViolation represents a unique (or primary key) constraint violation.
+	ErrorKindUniqueViolation
+	// ErrorKindForeignKeyViolation represents a foreign key constraint violation.
+	ErrorKindForeignKeyViolation
+	// ErrorKindNotNullViolation represents a not-null constraint violation.
+	ErrorKindNotNullViolation
+	// ErrorKindCheckViolation represents a check constraint violation.
+	ErrorKindCheckViolation
+	// ErrorKindDeadlock represents a deadlock.
+	ErrorKindDeadlock
+	// ErrorKindSerializationFailure represents a serialization failure of a transaction.
+	ErrorKindSerializationFailure
+	// ErrorKindLockTimeout represents a failure to acquire a lock in time.
+	ErrorKindLockTimeout
+	// ErrorKindQueryCanceled represents a query canceled by the server, a statement timeout or the context.
+	ErrorKindQueryCanceled
+	// ErrorKindConnection represents a broken or unavailable connection.
+	ErrorKindConnection
+)
+
+// String implements fmt.Stringer.
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindUnknown:
+		return "unknown"
+	case ErrorKindUniqueViolation:
+		return "unique violation"
+	case ErrorKindForeignKeyViolation:
+		return "foreign key violation"
+	case ErrorKindNotNullViolation:
+		return "not null violation"
+	case ErrorKindCheckViolation:
+		return "check violation"
+	case ErrorKindDeadlock:
+		return "deadlock"
+	case ErrorKindSerializationFailure:
+		return "serialization failure"
+	case ErrorKindLockTimeout:
+		return "lock timeout"
+	case ErrorKindQueryCanceled:
+		return "query canceled"
+	case ErrorKindConnection:
+		return "connection error"
+	default:
+		return fmt.Sprintf("ErrorKind(%d)", int(k))
+	}
+}
+
+var (
+	mysqlErrorKinds = map[uint16]ErrorKind{
+		1062: ErrorKindUniqueViolation,     // ER_DUP_ENTRY
+		1586: ErrorKindUniqueViolation,     // ER_DUP_ENTRY_WITH_KEY_NAME
+		1216: ErrorKindForeignKeyViolation, // ER_NO_REFERENCED_ROW
+		1217: ErrorKindForeignKeyViolation, // ER_ROW_IS_REFERENCED
+		1451: ErrorKindForeignKeyViolation, // ER_ROW_IS_REFERENCED_2
+		1452: ErrorKindForeignKeyViolation, // ER_NO_REFERENCED_ROW_2
+		1048: ErrorKindNotNullViolation,    // ER_BAD_NULL_ERROR
+		1364: ErrorKindNotNullViolation,    // ER_NO_DEFAULT_FOR_FIELD
+		3819: ErrorKindCheckViolation,      // ER_CHECK_CONSTRAINT_VIOLATED
+		1213: ErrorKindDeadlock,            // ER_LOCK_DEADLOCK
+		1205: ErrorKindLockTimeout,         // ER_LOCK_WAIT_TIMEOUT
+		3572: ErrorKindLockTimeout,         // ER_LOCK_NOWAIT
+		1317: ErrorKindQueryCanceled,       // ER_QUERY_INTERRUPTED
+		3024: ErrorKindQueryCanceled,       // ER_QUERY_TIMEOUT
+		1040: ErrorKindConnection,          // ER_CON_COUNT_ERROR
+		1053: ErrorKindConnection,          // ER_SERVER_SHUTDOWN
+	}
+	postgreSQLErrorKinds = map[string]ErrorKind{
+		"23505": ErrorKindUniqueViolation,
+		"23503": ErrorKindForeignKeyViolation,
+		"23502": ErrorKindNotNullViolation,
+		"23514": ErrorKindCheckViolation,
+		"40P01": ErrorKindDeadlock,
+		"40001": ErrorKindSerializationFailure,
+		"55P03": ErrorKindLockTimeout,
+		"57014": ErrorKindQueryCanceled,
+		"57P01": ErrorKindConnection,
+		"57P02": ErrorKindConnection,
+		"57P03": ErrorKindConnection,
+	}
+	sqliteErrorKinds = map[int]ErrorKind{
+		2067: ErrorKindUniqueViolation,     // SQLITE_CONSTRAINT_UNIQUE
+		1555: ErrorKindUniqueViolation,     // SQLITE_CONSTRAINT_PRIMARYKEY
+		787:  ErrorKindForeignKeyViolation, // SQLITE_CONSTRAINT_FOREIGNKEY
+		1299: ErrorKindNotNullViolation,    // SQLITE_CONSTRAINT_NOTNULL
+		275:  ErrorKindCheckViolation,      // SQLITE_CONSTRAINT_CHECK
+		5:    ErrorKindLockTimeout,         // SQLITE_BUSY
+		6:    ErrorKindLockTimeout,         // SQLITE_LOCKED
+	}
+	sqliteErrorMessageKinds = []struct {
+		prefix string
+		kind   ErrorKind
+	}{
+		{"UNIQUE constraint failed", ErrorKindUniqueViolation},
+		{"FOREIGN KEY constraint failed", ErrorKindForeignKeyViolation},
+		{"NOT NULL constraint failed", ErrorKindNotNullViolation},
+		{"CHECK constraint failed", ErrorKindCheckViolation},
+		{"database is locked", ErrorKindLockTimeout},
+		{"database table is locked", ErrorKindLockTimeout},
+	}
+)
+
+// ClassifyError returns the kind of a database error returned by a driver of the dialect.
+// PostgreSQL errors are recognized by their SQLSTATE (e.g. *pgconn.PgError),
+// and SQLite errors by their extended result code or, failing that, by their message.
+// Errors that are not specific to the driver,
+// such as context.Canceled and driver.ErrBadConn, are classified as well.
+func (d Dialect) ClassifyError(err error) ErrorKind {
+	if err == nil {
+		return ErrorKindUnknown
+	}
+
+	if kind, ok := d.classifyDriverError(err); ok {
+		return kind
+	}
+
+	return classifyGenericError(err)
+}
+
+// ClassifyError is like Dialect.ClassifyError,
+// but recognizes errors of the drivers of all dialects.
+func ClassifyError(err error) ErrorKind {
+	if err == nil {
+		return ErrorKindUnknown
+	}
+
+	for _, d := range []Dialect{DialectMySQL, DialectPostgreSQL, DialectSQLite} {
+		if kind, ok := d.classifyDriverError(err); ok {
+			return kind
+		}
+	}
+
+	return classifyGenericError(err)
+}
+
+// IsUniqueViolation reports whether err is a unique (or primary key) constraint violation.
+func IsUniqueViolation(err error) bool {
+	return ClassifyError(err) == ErrorKindUniqueViolation
+}
+
+// IsForeignKeyViolation reports whether err is a foreign key constraint violation.
+func IsForeignKeyViolation(err error) bool {
+	return ClassifyError(err) == ErrorKindForeignKeyViolation
+}
+
+// IsNotNullViolation reports whether err is a not-null constraint violation.
+func IsNotNullViolation(err error) bool {
+	return ClassifyError(err) == ErrorKindNotNullViolation
+}
+
+// IsCheckViolation reports whether err is a check constraint violation.
+func IsCheckViolation(err error) bool {
+	return ClassifyError(err) == ErrorKindCheckViolation
+}
+
+// IsDeadlock reports whether err is a deadlock.
+func IsDeadlock(err error) bool {
+	return ClassifyError(err) == ErrorKindDeadlock
+}
+
+// IsSerializationFailure reports whether err is a serialization failure of a transaction.
+func IsSerializationFailure(err error) bool {
+	return ClassifyError(err) == ErrorKindSerializationFailure
+}
+
+// IsLockTimeout reports whether err is a failure to acquire a lock in time.
+func IsLockTimeout(err error) bool {
+	return ClassifyError(err) == ErrorKindLockTimeout
+}
+
+// IsQueryCanceled reports whether err is a query canceled by the server, a statement timeout or the context.
+func IsQueryCanceled(err error) bool {
+	return ClassifyError(err) == ErrorKindQueryCanceled
+}
+
+// IsConnectionError reports whether err is a broken or unavailable connection.
+func IsConnectionError(err error) bool {
+	return ClassifyError(err) == ErrorKindConnection
+}
+
+var (
+	mysqlConstraintNamePatterns = []*regexp.Regexp{
+		regexp.MustCompile("for key '([^']+)'"),          // ER_DUP_ENTRY
+		regexp.MustCompile("CONSTRAINT `([^`]+)`"),       // ER_NO_REFERENCED_ROW_2, ER_ROW_IS_REFERENCED_2
+		regexp.MustCompile("Check constraint '([^']+)'"), // ER_CHECK_CONSTRAINT_VIOLATED
+	}
+	sqliteConstraintNamePattern = regexp.MustCompile(`CHECK constraint failed: (\S+)`)
+)
+
+// ConstraintName returns the name of the constraint violated by err,
+// or an empty string if it is not available.
+// PostgreSQL errors report it by *pgconn.PgError;
+// for MySQL and SQLite, it is extracted from the error message when the message includes it
+// (e.g. `task.PRIMARY` of a duplicate entry on MySQL 8.0).
+func ConstraintName(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		for _, re := range mysqlConstraintNamePatterns {
+			if m := re.FindStringSubmatch(myErr.Message); m != nil {
+				return m[1]
+			}
+		}
+
+		return ""
+	}
+
+	if m := sqliteConstraintNamePattern.FindStringSubmatch(innermostError(err).Error()); m != nil {
+		return m[1]
+	}
+
+	return ""
+}
+
+// classifyDriverError classifies an error specific to the driver of the dialect.
+// It reports false if err is not such an error.
+func (d Dialect) classifyDriverError(err error) (ErrorKind, bool) {
+	switch d {
+	case DialectMySQL:
+		var myErr *mysql.MySQLError
+		if errors.As(err, &myErr) {
+			return mysqlErrorKinds[myErr.Number], true
+		}
+		if errors.Is(err, mysql.ErrInvalidConn) {
+			return ErrorKindConnection, true
+		}
+	case DialectPostgreSQL:
+		var pgErr interface{ SQLState() string }
+		if errors.As(err, &pgErr) {
+			state := pgErr.SQLState()
+			if kind, ok := postgreSQLErrorKinds[state]; ok {
+				return kind, true
+			}
+			// class 08: connection exception
+			if strings.HasPrefix(state, "08") {
+				return ErrorKindConnection, true
+			}
+
+			return ErrorKindUnknown, true
+		}
+	case DialectSQLite:
+		var liteErr interface{ Code() int }
+		if errors.As(err, &liteErr) {
+			if kind, ok := sqliteErrorKinds[liteErr.Code()]; ok {
+				return kind, true
+			}
+		}
+
+		// wrappers such as QueryError may quote the query and arguments in their messages
+		msg := innermostError(err).Error()
+		for _, mk := range sqliteErrorMessageKinds {
+			if strings.Contains(msg, mk.prefix) {
+				return mk.kind, true
+			}
+		}
+	}
+
+	return ErrorKindUnknown, false
+}
+
+// innermostError returns the last error in the chain of err, which is usually the one returned by the driver.
+func innermostError(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+// classifyGenericError classifies an error not specific to any driver.
+func classifyGenericError(err error) ErrorKind {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return ErrorKindQueryCanceled
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone):
+		return ErrorKindConnection
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return ErrorKindQueryCanceled
+		}
+
+		return ErrorKindConnection
+	}
+
+	return ErrorKindUnknown
+}
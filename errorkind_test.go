@@ -0,0 +1,322 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type errorKindTestSQLiteError struct {
+	code int
+}
+
+func (e errorKindTestSQLiteError) Error() string {
+	return "sqlite error"
+}
+
+func (e errorKindTestSQLiteError) Code() int {
+	return e.code
+}
+
+func TestErrorKind_String(t *testing.T) {
+	require.Equal(t, "unique violation", sqlutil.ErrorKindUniqueViolation.String())
+	require.Equal(t, "ErrorKind(100)", sqlutil.ErrorKind(100).String())
+}
+
+func TestDialect_ClassifyError(t *testing.T) {
+	tcs := []struct {
+		name    string
+		dialect sqlutil.Dialect
+		in      error
+		want    sqlutil.ErrorKind
+	}{
+		{
+			"nil",
+			sqlutil.DialectMySQL,
+			nil,
+			sqlutil.ErrorKindUnknown,
+		},
+		{
+			"unknown",
+			sqlutil.DialectPostgreSQL,
+			errors.New("something went wrong"),
+			sqlutil.ErrorKindUnknown,
+		},
+		{
+			"mysql: unique violation",
+			sqlutil.DialectMySQL,
+			fmt.Errorf("wrapped: %w", &mysql.MySQLError{Number: 1062}),
+			sqlutil.ErrorKindUniqueViolation,
+		},
+		{
+			"mysql: deadlock",
+			sqlutil.DialectMySQL,
+			&mysql.MySQLError{Number: 1213},
+			sqlutil.ErrorKindDeadlock,
+		},
+		{
+			"mysql: unknown",
+			sqlutil.DialectMySQL,
+			&mysql.MySQLError{Number: 1064},
+			sqlutil.ErrorKindUnknown,
+		},
+		{
+			"postgresql: foreign key violation",
+			sqlutil.DialectPostgreSQL,
+			fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "23503"}),
+			sqlutil.ErrorKindForeignKeyViolation,
+		},
+		{
+			"postgresql: serialization failure",
+			sqlutil.DialectPostgreSQL,
+			&pgconn.PgError{Code: "40001"},
+			sqlutil.ErrorKindSerializationFailure,
+		},
+		{
+			"postgresql: mysql error",
+			sqlutil.DialectPostgreSQL,
+			&mysql.MySQLError{Number: 1062},
+			sqlutil.ErrorKindUnknown,
+		},
+		{
+			"sqlite: code",
+			sqlutil.DialectSQLite,
+			errorKindTestSQLiteError{1299},
+			sqlutil.ErrorKindNotNullViolation,
+		},
+		{
+			"sqlite: message",
+			sqlutil.DialectSQLite,
+			errors.New("UNIQUE constraint failed: task.id"),
+			sqlutil.ErrorKindUniqueViolation,
+		},
+		{
+			"sqlite: wrapped message",
+			sqlutil.DialectSQLite,
+			&sqlutil.QueryError{Op: sqlutil.OpExec, Err: errors.New("UNIQUE constraint failed: task.id")},
+			sqlutil.ErrorKindUniqueViolation,
+		},
+		{
+			"sqlite: message in query",
+			sqlutil.DialectSQLite,
+			&sqlutil.QueryError{Op: sqlutil.OpQuery, Query: "SELECT 'database is locked'", Err: context.Canceled},
+			sqlutil.ErrorKindQueryCanceled,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.dialect.ClassifyError(tc.in))
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tcs := []struct {
+		name string
+		in   error
+		want sqlutil.ErrorKind
+	}{
+		{
+			"nil",
+			nil,
+			sqlutil.ErrorKindUnknown,
+		},
+		{
+			"unknown",
+			errors.New("something went wrong"),
+			sqlutil.ErrorKindUnknown,
+		},
+		{
+			"mysql: check violation",
+			fmt.Errorf("wrapped: %w", &mysql.MySQLError{Number: 3819}),
+			sqlutil.ErrorKindCheckViolation,
+		},
+		{
+			"mysql: query canceled",
+			&mysql.MySQLError{Number: 1317},
+			sqlutil.ErrorKindQueryCanceled,
+		},
+		{
+			"mysql: invalid connection",
+			fmt.Errorf("wrapped: %w", mysql.ErrInvalidConn),
+			sqlutil.ErrorKindConnection,
+		},
+		{
+			"postgresql: unique violation",
+			fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "23505"}),
+			sqlutil.ErrorKindUniqueViolation,
+		},
+		{
+			"postgresql: query canceled",
+			&pgconn.PgError{Code: "57014"},
+			sqlutil.ErrorKindQueryCanceled,
+		},
+		{
+			"postgresql: connection exception",
+			&pgconn.PgError{Code: "08006"},
+			sqlutil.ErrorKindConnection,
+		},
+		{
+			"sqlite: lock timeout",
+			errorKindTestSQLiteError{5},
+			sqlutil.ErrorKindLockTimeout,
+		},
+		{
+			"context canceled",
+			fmt.Errorf("wrapped: %w", context.Canceled),
+			sqlutil.ErrorKindQueryCanceled,
+		},
+		{
+			"context canceled: sqlite message in query and args",
+			&sqlutil.QueryError{
+				Op:    sqlutil.OpExec,
+				Query: "INSERT INTO task (title) VALUES ('UNIQUE constraint failed')",
+				Args:  []any{"database is locked"},
+				Err:   context.Canceled,
+			},
+			sqlutil.ErrorKindQueryCanceled,
+		},
+		{
+			"bad connection: sqlite message in query",
+			&sqlutil.QueryError{Op: sqlutil.OpQuery, Query: "SELECT 'database is locked'", Err: driver.ErrBadConn},
+			sqlutil.ErrorKindConnection,
+		},
+		{
+			"bad connection",
+			fmt.Errorf("wrapped: %w", driver.ErrBadConn),
+			sqlutil.ErrorKindConnection,
+		},
+		{
+			"network error",
+			&net.OpError{Op: "dial", Err: errors.New("connection refused")},
+			sqlutil.ErrorKindConnection,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, sqlutil.ClassifyError(tc.in))
+		})
+	}
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	require.True(t, sqlutil.IsUniqueViolation(&mysql.MySQLError{Number: 1062}))
+	require.True(t, sqlutil.IsUniqueViolation(&pgconn.PgError{Code: "23505"}))
+	require.True(t, sqlutil.IsUniqueViolation(fmt.Errorf("failed to insert rows: %w", &pgconn.PgError{Code: "23505"})))
+	require.False(t, sqlutil.IsUniqueViolation(&pgconn.PgError{Code: "23503"}))
+	require.False(t, sqlutil.IsUniqueViolation(nil))
+}
+
+func TestConstraintName(t *testing.T) {
+	tcs := []struct {
+		name string
+		in   error
+		want string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		},
+		{
+			"mysql: duplicate entry",
+			fmt.Errorf("wrapped: %w", &mysql.MySQLError{
+				Number:  1062,
+				Message: "Duplicate entry '1' for key 'task.PRIMARY'",
+			}),
+			"task.PRIMARY",
+		},
+		{
+			"mysql: foreign key",
+			&mysql.MySQLError{
+				Number:  1452,
+				Message: "Cannot add or update a child row: a foreign key constraint fails (`sqlutil`.`comment`, CONSTRAINT `comment_task_fk` FOREIGN KEY (`task_id`) REFERENCES `task` (`id`))",
+			},
+			"comment_task_fk",
+		},
+		{
+			"mysql: check",
+			&mysql.MySQLError{
+				Number:  3819,
+				Message: "Check constraint 'task_title_check' is violated.",
+			},
+			"task_title_check",
+		},
+		{
+			"postgresql",
+			fmt.Errorf("wrapped: %w", &pgconn.PgError{
+				Code:           "23505",
+				ConstraintName: "task_pkey",
+			}),
+			"task_pkey",
+		},
+		{
+			"sqlite: check",
+			errors.New("CHECK constraint failed: task_title_check"),
+			"task_title_check",
+		},
+		{
+			"sqlite: unique",
+			errors.New("UNIQUE constraint failed: task.id"),
+			"",
+		},
+		{
+			"sqlite: message in query",
+			&sqlutil.QueryError{Op: sqlutil.OpExec, Query: "SELECT 'CHECK constraint failed: x'", Err: errors.New("something went wrong")},
+			"",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, sqlutil.ConstraintName(tc.in))
+		})
+	}
+}
+
+func TestClassifyError_DB(t *testing.T) {
+	tcs := []struct {
+		name               string
+		db                 *sql.DB
+		wantConstraintName string
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			"task.PRIMARY",
+		},
+		{
+			"postgresql",
+			psqlDB,
+			"task_pkey",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			ctx := t.Context()
+
+			err := sqlutil.Transact(ctx, tc.db, func(ctx context.Context, tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, `INSERT INTO task (id, title, url) VALUES (1, 'task1', 'http://m0t0k1ch1.com/task/1')`)
+
+				return err
+			})
+			require.True(t, sqlutil.IsUniqueViolation(err))
+			require.Equal(t, tc.wantConstraintName, sqlutil.ConstraintName(err))
+		})
+	}
+}
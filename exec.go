@@ -0,0 +1,119 @@
+package sqlutil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNoRowsAffected is returned when a statement affects no rows but some are expected.
+	ErrNoRowsAffected = errors.New("no rows affected")
+	// ErrTooFewRowsAffected is returned when a statement affects fewer rows than expected, but not none.
+	ErrTooFewRowsAffected = errors.New("too few rows affected")
+	// ErrTooManyRowsAffected is returned when a statement affects more rows than expected.
+	ErrTooManyRowsAffected = errors.New("too many rows affected")
+)
+
+// RowsExpectation is the expected number of rows affected by a statement.
+type RowsExpectation struct {
+	min            int64
+	max            int64
+	allowUnchanged bool
+}
+
+// ExactRows expects exactly n rows to be affected.
+func ExactRows(n int64) RowsExpectation {
+	return RowsExpectation{
+		min: n,
+		max: n,
+	}
+}
+
+// RowsBetween expects between minRows and maxRows (inclusive) to be affected.
+func RowsBetween(minRows, maxRows int64) RowsExpectation {
+	return RowsExpectation{
+		min: minRows,
+		max: maxRows,
+	}
+}
+
+// AllowUnchanged returns a copy of the expectation that only checks the upper bound.
+//
+// MySQL counts the rows actually changed by an UPDATE, so a row that matches the WHERE clause
+// but already has the new values is not counted,
+// and the lower bound would reject an UPDATE that found its rows.
+// Use this for such UPDATEs on MySQL,
+// or alternatively set `clientFoundRows=true` in the DSN of github.com/go-sql-driver/mysql
+// to count matched rows instead, as PostgreSQL and SQLite do.
+func (e RowsExpectation) AllowUnchanged() RowsExpectation {
+	e.allowUnchanged = true
+
+	return e
+}
+
+// String implements fmt.Stringer.
+func (e RowsExpectation) String() string {
+	switch {
+	case e.allowUnchanged:
+		return fmt.Sprintf("at most %d", e.max)
+	case e.min == e.max:
+		return fmt.Sprintf("%d", e.min)
+	default:
+		return fmt.Sprintf("%d to %d", e.min, e.max)
+	}
+}
+
+func (e RowsExpectation) check(n int64) error {
+	switch {
+	case n > e.max:
+		return fmt.Errorf("%w: %d rows, want %s", ErrTooManyRowsAffected, n, e)
+	case n >= e.min || e.allowUnchanged:
+		return nil
+	case n == 0:
+		return fmt.Errorf("%w: want %s", ErrNoRowsAffected, e)
+	default:
+		return fmt.Errorf("%w: %d rows, want %s", ErrTooFewRowsAffected, n, e)
+	}
+}
+
+// ExecExpect executes the statement and checks that the number of rows affected meets the expectation,
+// returning ErrNoRowsAffected, ErrTooFewRowsAffected or ErrTooManyRowsAffected otherwise.
+// Within Transact, returning the error rolls back the statement along with the rest of the transaction.
+func ExecExpect(ctx context.Context, queryExecutor QueryExecutor, expect RowsExpectation, query string, args ...any) (sql.Result, error) {
+	res, err := execContext(ctx, queryExecutor, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := expect.check(n); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ExecOne executes the statement and checks that it affects exactly one row.
+// See ExecExpect.
+func ExecOne(ctx context.Context, queryExecutor QueryExecutor, query string, args ...any) (sql.Result, error) {
+	return ExecExpect(ctx, queryExecutor, ExactRows(1), query, args...)
+}
+
+// execContext executes the statement, returning a *QueryError if it fails.
+func execContext(ctx context.Context, queryExecutor QueryExecutor, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+
+	res, err := queryExecutor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, newQueryError(ctx, OpExec, query, args, start, err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,170 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type execTestQueryExecutor struct {
+	rowsAffected int64
+}
+
+func (qe execTestQueryExecutor) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return execTestResult(qe.rowsAffected), nil
+}
+
+type execTestResult int64
+
+func (r execTestResult) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (r execTestResult) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+func TestExecExpect(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name         string
+			expect       sqlutil.RowsExpectation
+			rowsAffected int64
+			wantErr      error
+			want         string
+		}{
+			{
+				"no rows affected",
+				sqlutil.ExactRows(1),
+				0,
+				sqlutil.ErrNoRowsAffected,
+				"no rows affected: want 1",
+			},
+			{
+				"too few rows affected",
+				sqlutil.RowsBetween(2, 3),
+				1,
+				sqlutil.ErrTooFewRowsAffected,
+				"too few rows affected: 1 rows, want 2 to 3",
+			},
+			{
+				"too many rows affected",
+				sqlutil.ExactRows(1),
+				2,
+				sqlutil.ErrTooManyRowsAffected,
+				"too many rows affected: 2 rows, want 1",
+			},
+			{
+				"too many rows affected: unchanged allowed",
+				sqlutil.ExactRows(1).AllowUnchanged(),
+				2,
+				sqlutil.ErrTooManyRowsAffected,
+				"too many rows affected: 2 rows, want at most 1",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.ExecExpect(t.Context(), execTestQueryExecutor{tc.rowsAffected}, tc.expect, `UPDATE task SET is_completed = true`)
+				require.ErrorIs(t, err, tc.wantErr)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name         string
+			expect       sqlutil.RowsExpectation
+			rowsAffected int64
+		}{
+			{
+				"exact",
+				sqlutil.ExactRows(1),
+				1,
+			},
+			{
+				"between",
+				sqlutil.RowsBetween(0, 2),
+				2,
+			},
+			{
+				"unchanged allowed",
+				sqlutil.ExactRows(1).AllowUnchanged(),
+				0,
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				res, err := sqlutil.ExecExpect(t.Context(), execTestQueryExecutor{tc.rowsAffected}, tc.expect, `UPDATE task SET is_completed = true`)
+				require.NoError(t, err)
+
+				n, err := res.RowsAffected()
+				require.NoError(t, err)
+				require.Equal(t, tc.rowsAffected, n)
+			})
+		}
+	})
+}
+
+func TestExecOne(t *testing.T) {
+	tcs := []struct {
+		name    string
+		db      *sql.DB
+		dialect sqlutil.Dialect
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			sqlutil.DialectMySQL,
+		},
+		{
+			"postgresql",
+			psqlDB,
+			sqlutil.DialectPostgreSQL,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			query := `UPDATE task SET is_completed = true WHERE id = ` + tc.dialect.Placeholder(1)
+
+			t.Run("failure: rollback on no rows affected", func(t *testing.T) {
+				ctx := t.Context()
+
+				err := sqlutil.Transact(ctx, tc.db, func(ctx context.Context, tx *sql.Tx) error {
+					if _, err := sqlutil.ExecOne(ctx, tx, query, 1); err != nil {
+						return err
+					}
+
+					_, err := sqlutil.ExecOne(ctx, tx, query, 0)
+
+					return err
+				})
+				require.ErrorIs(t, err, sqlutil.ErrNoRowsAffected)
+
+				completed, err := sqlutil.QueryScalar[bool](ctx, tc.db, `SELECT is_completed FROM task WHERE id = 1`)
+				require.NoError(t, err)
+				require.False(t, completed)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.ExecOne(ctx, tc.db, query, 1)
+				require.NoError(t, err)
+
+				// MySQL reports 0 rows affected for a row already updated
+				_, err = sqlutil.ExecExpect(ctx, tc.db, sqlutil.ExactRows(1).AllowUnchanged(), query, 1)
+				require.NoError(t, err)
+			})
+		})
+	}
+}
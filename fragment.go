@@ -0,0 +1,51 @@
+package sqlutil
+
+import (
+	"slices"
+	"strings"
+)
+
+// trustedString is a string that can only be passed as an untyped constant
+// from outside the package, since a variable of type string is not assignable to it.
+type trustedString string
+
+// Fragment is a trusted fragment of SQL,
+// which can only be constructed from constants or quoted identifiers.
+// It is intended for structural positions such as table names and ORDER BY clauses,
+// where bind parameters cannot be used.
+type Fragment struct {
+	s     string
+	names []string // the unquoted names if it is an identifier
+}
+
+// NewFragment returns a Fragment of the given constant SQL.
+// Only untyped constants are accepted, so user input cannot be passed by accident.
+func NewFragment(s trustedString) Fragment {
+	return Fragment{s: string(s)}
+}
+
+// Identifier returns a Fragment of the quoted (possibly qualified) identifier.
+// See QuoteQualifiedIdentifier.
+func (d Dialect) Identifier(names ...string) Fragment {
+	return Fragment{d.QuoteQualifiedIdentifier(names...), slices.Clone(names)}
+}
+
+// JoinFragments concatenates the fragments, placing sep between them.
+func JoinFragments(sep trustedString, fs ...Fragment) Fragment {
+	ss := make([]string, len(fs))
+	for i, f := range fs {
+		ss[i] = f.s
+	}
+
+	return Fragment{s: strings.Join(ss, string(sep))}
+}
+
+// IsZero reports whether the fragment is empty.
+func (f Fragment) IsZero() bool {
+	return f.s == ""
+}
+
+// String implements fmt.Stringer.
+func (f Fragment) String() string {
+	return f.s
+}
@@ -0,0 +1,50 @@
+package sqlutil_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestFragment(t *testing.T) {
+	const desc = "DESC"
+
+	tcs := []struct {
+		name string
+		in   sqlutil.Fragment
+		want string
+	}{
+		{
+			"zero",
+			sqlutil.Fragment{},
+			"",
+		},
+		{
+			"constant",
+			sqlutil.NewFragment(desc),
+			"DESC",
+		},
+		{
+			"identifier",
+			sqlutil.DialectPostgreSQL.Identifier("public", `ta"sk`),
+			`"public"."ta""sk"`,
+		},
+		{
+			"join",
+			sqlutil.JoinFragments(", ",
+				sqlutil.JoinFragments(" ", sqlutil.DialectMySQL.Identifier("is_completed"), sqlutil.NewFragment("ASC")),
+				sqlutil.JoinFragments(" ", sqlutil.DialectMySQL.Identifier("id"), sqlutil.NewFragment(desc)),
+			),
+			"`is_completed` ASC, `id` DESC",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.in.String())
+			require.Equal(t, tc.want == "", tc.in.IsZero())
+		})
+	}
+}
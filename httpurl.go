@@ -127,6 +127,21 @@ func (hu *HTTPURL) Scan(src any) error {
 	return nil
 }
 
+// MarshalText implements encoding.TextMarshaler.
+// It returns the value as a string.
+func (hu HTTPURL) MarshalText() ([]byte, error) {
+	return []byte(hu.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (hu *HTTPURL) UnmarshalText(b []byte) error {
+	if err := hu.setString(string(b)); err != nil {
+		return fmt.Errorf("invalid text: %w", err)
+	}
+
+	return nil
+}
+
 // MarshalJSON implements json.Marshaler.
 // It returns the value as a JSON string.
 func (hu HTTPURL) MarshalJSON() ([]byte, error) {
@@ -3,6 +3,7 @@ package sqlutil_test
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -18,6 +19,8 @@ func TestHTTPURL(t *testing.T) {
 	require.Implements(t, (*fmt.Stringer)(nil), &hu)
 	require.Implements(t, (*driver.Valuer)(nil), &hu)
 	require.Implements(t, (*sql.Scanner)(nil), &hu)
+	require.Implements(t, (*encoding.TextMarshaler)(nil), &hu)
+	require.Implements(t, (*encoding.TextUnmarshaler)(nil), &hu)
 	require.Implements(t, (*json.Marshaler)(nil), &hu)
 	require.Implements(t, (*json.Unmarshaler)(nil), &hu)
 }
@@ -401,6 +404,82 @@ func TestHTTPURL_Scan(t *testing.T) {
 	})
 }
 
+func TestHTTPURL_MarshalText(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   sqlutil.HTTPURL
+			want []byte
+		}{
+			{
+				"http",
+				sqlutil.MustNewHTTPURLFromString("http://m0t0k1ch1.com"),
+				[]byte("http://m0t0k1ch1.com"),
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				b, err := tc.in.MarshalText()
+				require.NoError(t, err)
+				require.Equal(t, tc.want, b)
+			})
+		}
+	})
+}
+
+func TestHTTPURL_UnmarshalText(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   []byte
+			want string
+		}{
+			{
+				"empty",
+				[]byte{},
+				"invalid text: invalid url string: empty",
+			},
+			{
+				"invalid scheme: ftp",
+				[]byte("ftp://m0t0k1ch1.com"),
+				"invalid text: invalid url.URL: invalid scheme: must be http or https",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var hu sqlutil.HTTPURL
+				err := hu.UnmarshalText(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   []byte
+			want string
+		}{
+			{
+				"https",
+				[]byte("https://m0t0k1ch1.com"),
+				"https://m0t0k1ch1.com",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var hu sqlutil.HTTPURL
+				err := hu.UnmarshalText(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, hu.String())
+			})
+		}
+	})
+}
+
 func TestHTTPURL_MarshalJSON(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		tcs := []struct {
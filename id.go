@@ -0,0 +1,297 @@
+package sqlutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// JSONStringID is implemented by an entity type whose ID[Entity] is encoded as a JSON string.
+// This is useful for JavaScript clients, which cannot represent integers above 2^53-1.
+type JSONStringID interface {
+	JSONStringID() bool
+}
+
+// ID represents an int64 identifier (BIGINT) of the given entity type.
+// IDs of different entity types are distinct types, so mixing them up fails to compile.
+type ID[Entity any] struct {
+	v int64
+}
+
+// NewID returns a new ID.
+func NewID[Entity any](v int64) ID[Entity] {
+	return ID[Entity]{
+		v: v,
+	}
+}
+
+// NewIDFromString returns a new ID from a decimal string.
+func NewIDFromString[Entity any](s string) (ID[Entity], error) {
+	var id ID[Entity]
+	if err := id.setString(s); err != nil {
+		return ID[Entity]{}, err
+	}
+
+	return id, nil
+}
+
+// MustNewIDFromString panics if the input is invalid.
+func MustNewIDFromString[Entity any](s string) ID[Entity] {
+	id, err := NewIDFromString[Entity](s)
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+func (id *ID[Entity]) setString(s string) error {
+	if len(s) == 0 {
+		return errors.New("invalid id string: empty")
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid id string: %w", err)
+	}
+
+	id.v = v
+
+	return nil
+}
+
+// Int64 returns the underlying int64.
+func (id ID[Entity]) Int64() int64 {
+	return id.v
+}
+
+// IsZero reports whether the value is zero.
+func (id ID[Entity]) IsZero() bool {
+	return id.v == 0
+}
+
+// String implements fmt.Stringer.
+// It returns the value as a decimal string.
+func (id ID[Entity]) String() string {
+	return strconv.FormatInt(id.v, 10)
+}
+
+// Value implements driver.Valuer.
+// It returns the value as an int64.
+func (id ID[Entity]) Value() (driver.Value, error) {
+	return id.v, nil
+}
+
+// Scan implements sql.Scanner.
+// It accepts an int64, string or []byte.
+func (id *ID[Entity]) Scan(src any) error {
+	if src == nil {
+		return errors.New("invalid source: nil")
+	}
+
+	var s string
+	{
+		switch v := src.(type) {
+		case int64:
+			id.v = v
+
+			return nil
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return fmt.Errorf("unsupported source type: %T", src)
+		}
+	}
+
+	if err := id.setString(s); err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the value as a decimal string.
+func (id ID[Entity]) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *ID[Entity]) UnmarshalText(b []byte) error {
+	if err := id.setString(string(b)); err != nil {
+		return fmt.Errorf("invalid text: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns the value as a JSON number,
+// or as a JSON string if Entity implements JSONStringID and returns true.
+func (id ID[Entity]) MarshalJSON() ([]byte, error) {
+	var e Entity
+	if jsi, ok := any(e).(JSONStringID); ok && jsi.JSONStringID() {
+		return json.Marshal(id.String())
+	}
+
+	return json.Marshal(id.v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a JSON number or a JSON string.
+func (id *ID[Entity]) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 {
+		return errors.New("invalid json value: empty")
+	}
+	if string(b) == "null" {
+		return errors.New("invalid json value: null")
+	}
+
+	if b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return fmt.Errorf("invalid json string: %w", err)
+		}
+
+		if err := id.setString(s); err != nil {
+			return fmt.Errorf("invalid json string: %w", err)
+		}
+
+		return nil
+	}
+
+	var v int64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("invalid json number: %w", err)
+	}
+
+	id.v = v
+
+	return nil
+}
+
+// StringID represents a string identifier (e.g. a UUID) of the given entity type.
+// IDs of different entity types are distinct types, so mixing them up fails to compile.
+type StringID[Entity any] struct {
+	v string
+}
+
+// NewStringID returns a new StringID.
+func NewStringID[Entity any](s string) (StringID[Entity], error) {
+	var id StringID[Entity]
+	if err := id.setString(s); err != nil {
+		return StringID[Entity]{}, err
+	}
+
+	return id, nil
+}
+
+// MustNewStringID panics if the input is invalid.
+func MustNewStringID[Entity any](s string) StringID[Entity] {
+	id, err := NewStringID[Entity](s)
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+func (id *StringID[Entity]) setString(s string) error {
+	if len(s) == 0 {
+		return errors.New("invalid id string: empty")
+	}
+
+	id.v = s
+
+	return nil
+}
+
+// IsZero reports whether the value is empty.
+func (id StringID[Entity]) IsZero() bool {
+	return id.v == ""
+}
+
+// String implements fmt.Stringer.
+// It returns the value as a string.
+func (id StringID[Entity]) String() string {
+	return id.v
+}
+
+// Value implements driver.Valuer.
+// It returns the value as a string.
+func (id StringID[Entity]) Value() (driver.Value, error) {
+	return id.v, nil
+}
+
+// Scan implements sql.Scanner.
+// It accepts a string or []byte.
+func (id *StringID[Entity]) Scan(src any) error {
+	if src == nil {
+		return errors.New("invalid source: nil")
+	}
+
+	var s string
+	{
+		switch v := src.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return fmt.Errorf("unsupported source type: %T", src)
+		}
+	}
+
+	if err := id.setString(s); err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the value as a string.
+func (id StringID[Entity]) MarshalText() ([]byte, error) {
+	return []byte(id.v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *StringID[Entity]) UnmarshalText(b []byte) error {
+	if err := id.setString(string(b)); err != nil {
+		return fmt.Errorf("invalid text: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns the value as a JSON string.
+func (id StringID[Entity]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a JSON string.
+func (id *StringID[Entity]) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 {
+		return errors.New("invalid json value: empty")
+	}
+	if string(b) == "null" {
+		return errors.New("invalid json value: null")
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	if err := id.setString(s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type idTestUser struct{}
+
+type idTestJSUser struct{}
+
+func (idTestJSUser) JSONStringID() bool {
+	return true
+}
+
+func TestID(t *testing.T) {
+	var id sqlutil.ID[idTestUser]
+	require.Implements(t, (*fmt.Stringer)(nil), &id)
+	require.Implements(t, (*driver.Valuer)(nil), &id)
+	require.Implements(t, (*sql.Scanner)(nil), &id)
+	require.Implements(t, (*encoding.TextMarshaler)(nil), &id)
+	require.Implements(t, (*encoding.TextUnmarshaler)(nil), &id)
+	require.Implements(t, (*json.Marshaler)(nil), &id)
+	require.Implements(t, (*json.Unmarshaler)(nil), &id)
+}
+
+func TestNewIDFromString(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"empty",
+				"",
+				"invalid id string: empty",
+			},
+			{
+				"not a number",
+				"abc",
+				"invalid id string",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.NewIDFromString[idTestUser](tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		id, err := sqlutil.NewIDFromString[idTestUser]("9007199254740993")
+		require.NoError(t, err)
+		require.Equal(t, int64(9007199254740993), id.Int64())
+		require.Equal(t, sqlutil.NewID[idTestUser](9007199254740993), id)
+	})
+}
+
+func TestID_Value(t *testing.T) {
+	v, err := sqlutil.NewID[idTestUser](1).Value()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), v)
+}
+
+func TestID_Scan(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"nil",
+				nil,
+				"invalid source: nil",
+			},
+			{
+				"float64",
+				1.5,
+				"unsupported source type: float64",
+			},
+			{
+				"string: empty",
+				"",
+				"invalid source: invalid id string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var id sqlutil.ID[idTestUser]
+				err := id.Scan(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+		}{
+			{
+				"int64",
+				int64(1),
+			},
+			{
+				"string",
+				"1",
+			},
+			{
+				"[]byte",
+				[]byte("1"),
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var id sqlutil.ID[idTestUser]
+				err := id.Scan(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, int64(1), id.Int64())
+			})
+		}
+	})
+}
+
+func TestID_MarshalText(t *testing.T) {
+	b, err := sqlutil.NewID[idTestUser](1).MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), b)
+}
+
+func TestID_UnmarshalText(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		var id sqlutil.ID[idTestUser]
+		err := id.UnmarshalText([]byte{})
+		require.ErrorContains(t, err, "invalid text: invalid id string: empty")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var id sqlutil.ID[idTestUser]
+		err := id.UnmarshalText([]byte("1"))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), id.Int64())
+	})
+}
+
+func TestID_MarshalJSON(t *testing.T) {
+	t.Run("number", func(t *testing.T) {
+		b, err := sqlutil.NewID[idTestUser](9007199254740993).MarshalJSON()
+		require.NoError(t, err)
+		require.Equal(t, []byte(`9007199254740993`), b)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		b, err := sqlutil.NewID[idTestJSUser](9007199254740993).MarshalJSON()
+		require.NoError(t, err)
+		require.Equal(t, []byte(`"9007199254740993"`), b)
+	})
+}
+
+func TestID_UnmarshalJSON(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   []byte
+			want string
+		}{
+			{
+				"empty",
+				[]byte{},
+				"invalid json value: empty",
+			},
+			{
+				"null",
+				[]byte(`null`),
+				"invalid json value: null",
+			},
+			{
+				"bool",
+				[]byte(`true`),
+				"invalid json number",
+			},
+			{
+				"string: empty",
+				[]byte(`""`),
+				"invalid json string: invalid id string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var id sqlutil.ID[idTestUser]
+				err := id.UnmarshalJSON(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   []byte
+		}{
+			{
+				"number",
+				[]byte(`9007199254740993`),
+			},
+			{
+				"string",
+				[]byte(`"9007199254740993"`),
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var id sqlutil.ID[idTestUser]
+				err := id.UnmarshalJSON(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, int64(9007199254740993), id.Int64())
+			})
+		}
+	})
+}
+
+func TestStringID(t *testing.T) {
+	var id sqlutil.StringID[idTestUser]
+	require.Implements(t, (*fmt.Stringer)(nil), &id)
+	require.Implements(t, (*driver.Valuer)(nil), &id)
+	require.Implements(t, (*sql.Scanner)(nil), &id)
+	require.Implements(t, (*encoding.TextMarshaler)(nil), &id)
+	require.Implements(t, (*encoding.TextUnmarshaler)(nil), &id)
+	require.Implements(t, (*json.Marshaler)(nil), &id)
+	require.Implements(t, (*json.Unmarshaler)(nil), &id)
+}
+
+func TestNewStringID(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		_, err := sqlutil.NewStringID[idTestUser]("")
+		require.ErrorContains(t, err, "invalid id string: empty")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		id, err := sqlutil.NewStringID[idTestUser]("0b5c1b9e-4a3f-4d0e-9f7a-3c2b1a0d9e8f")
+		require.NoError(t, err)
+		require.Equal(t, "0b5c1b9e-4a3f-4d0e-9f7a-3c2b1a0d9e8f", id.String())
+	})
+}
+
+func TestStringID_Scan(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"nil",
+				nil,
+				"invalid source: nil",
+			},
+			{
+				"int64",
+				int64(1),
+				"unsupported source type: int64",
+			},
+			{
+				"string: empty",
+				"",
+				"invalid source: invalid id string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var id sqlutil.StringID[idTestUser]
+				err := id.Scan(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var id sqlutil.StringID[idTestUser]
+		err := id.Scan([]byte("abc"))
+		require.NoError(t, err)
+		require.Equal(t, "abc", id.String())
+
+		v, err := id.Value()
+		require.NoError(t, err)
+		require.Equal(t, "abc", v)
+	})
+}
+
+func TestStringID_JSON(t *testing.T) {
+	id := sqlutil.MustNewStringID[idTestUser]("abc")
+
+	b, err := json.Marshal(id)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`"abc"`), b)
+
+	var got sqlutil.StringID[idTestUser]
+	require.NoError(t, json.Unmarshal(b, &got))
+	require.Equal(t, id, got)
+
+	err = got.UnmarshalJSON([]byte(`null`))
+	require.ErrorContains(t, err, "invalid json value: null")
+}
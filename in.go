@@ -0,0 +1,128 @@
+package sqlutil
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// InOption configures how ExpandIn expands slice arguments.
+type InOption func(*inConfig)
+
+type inConfig struct {
+	allowEmpty bool
+}
+
+// WithAllowEmptySlice makes an empty slice expand to NULL instead of returning an error.
+// Note that `x IN (NULL)` matches no rows, and so does `x NOT IN (NULL)`.
+func WithAllowEmptySlice() InOption {
+	return func(conf *inConfig) {
+		conf.allowEmpty = true
+	}
+}
+
+// ExpandIn expands each slice or array argument into one placeholder per element,
+// so that `WHERE id IN (?)` with []int64{1, 2, 3} becomes `WHERE id IN (?, ?, ?)` on MySQL and SQLite,
+// and `WHERE id IN ($1)` becomes `WHERE id IN ($1, $2, $3)` on PostgreSQL,
+// renumbering the following placeholders.
+// []byte, byte arrays and driver.Valuer types (e.g. List) are not expanded.
+// An empty slice is an error unless WithAllowEmptySlice is specified.
+//
+// To use it with named parameters, pass the result of BindNamed:
+//
+//	q, args, err := BindNamed(dialect, `SELECT * FROM task WHERE id IN (:ids)`, map[string]any{"ids": ids})
+//	q, args, err = ExpandIn(dialect, q, args)
+func ExpandIn(dialect Dialect, query string, args []any, opts ...InOption) (string, []any, error) {
+	var conf inConfig
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	var (
+		newArgs []any
+		lists   = make([]string, len(args))
+	)
+	for i, arg := range args {
+		vs, ok := expandArg(arg)
+		if !ok {
+			newArgs = append(newArgs, arg)
+			lists[i] = dialect.Placeholder(len(newArgs))
+
+			continue
+		}
+
+		if len(vs) == 0 {
+			if !conf.allowEmpty {
+				return "", nil, fmt.Errorf("invalid argument: empty slice: argument %d", i+1)
+			}
+
+			lists[i] = "NULL"
+
+			continue
+		}
+
+		phs := make([]string, len(vs))
+		for j, v := range vs {
+			newArgs = append(newArgs, v)
+			phs[j] = dialect.Placeholder(len(newArgs))
+		}
+		lists[i] = strings.Join(phs, ", ")
+	}
+
+	var n int
+
+	q, err := rewriteParams(dialect, query, func(param string) (string, error) {
+		switch param[0] {
+		case '?':
+			if n >= len(args) {
+				return "", errors.New("invalid query: more placeholders than arguments")
+			}
+			n++
+
+			return lists[n-1], nil
+		case '$':
+			i, err := strconv.Atoi(param[1:])
+			if err != nil || i < 1 || i > len(args) {
+				return "", fmt.Errorf("invalid query: no argument for placeholder: %s", param)
+			}
+
+			return lists[i-1], nil
+		default:
+			return param, nil
+		}
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if dialect != DialectPostgreSQL && n != len(args) {
+		return "", nil, fmt.Errorf("invalid query: %d placeholders for %d arguments", n, len(args))
+	}
+
+	return q, newArgs, nil
+}
+
+// expandArg returns the elements of arg if it is to be expanded.
+func expandArg(arg any) ([]any, bool) {
+	if _, ok := arg.(driver.Valuer); ok {
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(arg)
+	if k := rv.Kind(); k != reflect.Slice && k != reflect.Array {
+		return nil, false
+	}
+	if rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	vs := make([]any, rv.Len())
+	for i := range vs {
+		vs[i] = rv.Index(i).Interface()
+	}
+
+	return vs, true
+}
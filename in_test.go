@@ -0,0 +1,195 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestExpandIn(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name    string
+			dialect sqlutil.Dialect
+			query   string
+			args    []any
+			want    string
+		}{
+			{
+				"unsupported dialect",
+				0,
+				`SELECT * FROM task WHERE id IN (?)`,
+				[]any{[]int{1}},
+				"unsupported dialect: Dialect(0)",
+			},
+			{
+				"empty slice",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE id IN (?)`,
+				[]any{[]int{}},
+				"invalid argument: empty slice: argument 1",
+			},
+			{
+				"mysql: too many placeholders",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE id IN (?) AND title = ?`,
+				[]any{[]int{1}},
+				"invalid query: more placeholders than arguments",
+			},
+			{
+				"mysql: too few placeholders",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE id IN (?)`,
+				[]any{[]int{1}, "task1"},
+				"invalid query: 1 placeholders for 2 arguments",
+			},
+			{
+				"postgresql: no argument",
+				sqlutil.DialectPostgreSQL,
+				`SELECT * FROM task WHERE id IN ($1) AND title = $2`,
+				[]any{[]int{1}},
+				"invalid query: no argument for placeholder: $2",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, _, err := sqlutil.ExpandIn(tc.dialect, tc.query, tc.args)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name      string
+			dialect   sqlutil.Dialect
+			query     string
+			args      []any
+			opts      []sqlutil.InOption
+			wantQuery string
+			wantArgs  []any
+		}{
+			{
+				"mysql",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE title = ? AND id IN (?) AND url <> '?'`,
+				[]any{"task1", []int{1, 2, 3}},
+				nil,
+				`SELECT * FROM task WHERE title = ? AND id IN (?, ?, ?) AND url <> '?'`,
+				[]any{"task1", 1, 2, 3},
+			},
+			{
+				"postgresql",
+				sqlutil.DialectPostgreSQL,
+				`SELECT * FROM task WHERE id IN ($1) AND title = $2 OR id IN ($1)`,
+				[]any{[2]int64{1, 2}, "task1"},
+				nil,
+				`SELECT * FROM task WHERE id IN ($1, $2) AND title = $3 OR id IN ($1, $2)`,
+				[]any{int64(1), int64(2), "task1"},
+			},
+			{
+				"not expanded: []byte and driver.Valuer",
+				sqlutil.DialectPostgreSQL,
+				`SELECT $1, $2`,
+				[]any{[]byte("a"), sqlutil.NewList([]int{1}, sqlutil.DialectPostgreSQL)},
+				nil,
+				`SELECT $1, $2`,
+				[]any{[]byte("a"), sqlutil.NewList([]int{1}, sqlutil.DialectPostgreSQL)},
+			},
+			{
+				"empty slice allowed",
+				sqlutil.DialectPostgreSQL,
+				`SELECT * FROM task WHERE id IN ($1) AND title = $2`,
+				[]any{[]int{}, "task1"},
+				[]sqlutil.InOption{sqlutil.WithAllowEmptySlice()},
+				`SELECT * FROM task WHERE id IN (NULL) AND title = $1`,
+				[]any{"task1"},
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				q, args, err := sqlutil.ExpandIn(tc.dialect, tc.query, tc.args, tc.opts...)
+				require.NoError(t, err)
+				require.Equal(t, tc.wantQuery, q)
+				require.Equal(t, tc.wantArgs, args)
+			})
+		}
+	})
+
+	t.Run("success: named", func(t *testing.T) {
+		tcs := []struct {
+			name      string
+			dialect   sqlutil.Dialect
+			wantQuery string
+		}{
+			{
+				"mysql",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE id IN (?, ?) AND title = ? OR id IN (?, ?)`,
+			},
+			{
+				"postgresql",
+				sqlutil.DialectPostgreSQL,
+				`SELECT * FROM task WHERE id IN ($1, $2) AND title = $3 OR id IN ($1, $2)`,
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				q, args, err := sqlutil.BindNamed(tc.dialect, `SELECT * FROM task WHERE id IN (:ids) AND title = :title OR id IN (:ids)`, map[string]any{
+					"ids":   []int{1, 2},
+					"title": "task1",
+				})
+				require.NoError(t, err)
+
+				q, _, err = sqlutil.ExpandIn(tc.dialect, q, args)
+				require.NoError(t, err)
+				require.Equal(t, tc.wantQuery, q)
+			})
+		}
+	})
+
+	t.Run("success: db", func(t *testing.T) {
+		tcs := []struct {
+			name    string
+			db      *sql.DB
+			dialect sqlutil.Dialect
+		}{
+			{
+				"mysql",
+				mysqlDB,
+				sqlutil.DialectMySQL,
+			},
+			{
+				"postgresql",
+				psqlDB,
+				sqlutil.DialectPostgreSQL,
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				setupTasks(t, tc.db)
+
+				ctx := t.Context()
+
+				q, args, err := sqlutil.BindNamed(tc.dialect, `SELECT id, title, url, is_completed FROM task WHERE id IN (:ids) ORDER BY id`, map[string]any{
+					"ids": []int{1, 2, 3},
+				})
+				require.NoError(t, err)
+
+				q, args, err = sqlutil.ExpandIn(tc.dialect, q, args)
+				require.NoError(t, err)
+
+				tasks, err := sqlutil.QueryAll[Task](ctx, tc.db, q, args...)
+				require.NoError(t, err)
+				require.Len(t, tasks, 2)
+			})
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package sqlutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// IPStorage represents how IPAddr and IPPrefix are stored in a column.
+type IPStorage int
+
+const (
+	// IPStorageText stores the text form (PostgreSQL inet/cidr, MySQL VARCHAR).
+	IPStorageText IPStorage = iota
+	// IPStorageBinary stores the 4/16-byte form (MySQL VARBINARY(16)).
+	// IPPrefix appends one byte for the prefix length (VARBINARY(17)).
+	IPStorageBinary
+)
+
+// IPAddr represents an IP address.
+type IPAddr struct {
+	a       netip.Addr
+	storage IPStorage
+}
+
+// NewIPAddr returns a new IPAddr.
+func NewIPAddr(a netip.Addr) (IPAddr, error) {
+	var ia IPAddr
+	if err := ia.setAddr(a); err != nil {
+		return IPAddr{}, err
+	}
+
+	return ia, nil
+}
+
+// MustNewIPAddr panics if the input is invalid.
+func MustNewIPAddr(a netip.Addr) IPAddr {
+	ia, err := NewIPAddr(a)
+	if err != nil {
+		panic(err)
+	}
+
+	return ia
+}
+
+func (ia *IPAddr) setAddr(a netip.Addr) error {
+	if !a.IsValid() {
+		return errors.New("invalid netip.Addr: zero value")
+	}
+	if a.Zone() != "" {
+		return errors.New("invalid netip.Addr: zone not supported")
+	}
+
+	ia.a = a
+
+	return nil
+}
+
+// NewIPAddrFromString returns a new IPAddr from a string.
+// It also accepts the PostgreSQL inet form with a full-length prefix (e.g. 192.0.2.1/32).
+func NewIPAddrFromString(s string) (IPAddr, error) {
+	var ia IPAddr
+	if err := ia.setString(s); err != nil {
+		return IPAddr{}, err
+	}
+
+	return ia, nil
+}
+
+// MustNewIPAddrFromString panics if the input is invalid.
+func MustNewIPAddrFromString(s string) IPAddr {
+	ia, err := NewIPAddrFromString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return ia
+}
+
+func (ia *IPAddr) setString(s string) error {
+	if len(s) == 0 {
+		return errors.New("invalid ip address string: empty")
+	}
+
+	var a netip.Addr
+	{
+		if strings.Contains(s, "/") {
+			p, err := netip.ParsePrefix(s)
+			if err != nil {
+				return fmt.Errorf("invalid ip address string: %w", err)
+			}
+			if !p.IsSingleIP() {
+				return errors.New("invalid ip address string: prefix must be full-length")
+			}
+
+			a = p.Addr()
+		} else {
+			var err error
+			if a, err = netip.ParseAddr(s); err != nil {
+				return fmt.Errorf("invalid ip address string: %w", err)
+			}
+		}
+	}
+
+	return ia.setAddr(a)
+}
+
+func (ia *IPAddr) setBytes(b []byte) error {
+	a, ok := netip.AddrFromSlice(b)
+	if !ok {
+		return fmt.Errorf("invalid ip address bytes: length must be 4 or 16: %d", len(b))
+	}
+
+	return ia.setAddr(a)
+}
+
+// WithStorage returns a copy of the value with the given storage.
+func (ia IPAddr) WithStorage(storage IPStorage) IPAddr {
+	ia.storage = storage
+
+	return ia
+}
+
+// Storage returns the storage used by Value.
+func (ia IPAddr) Storage() IPStorage {
+	return ia.storage
+}
+
+// Addr returns the underlying netip.Addr.
+func (ia IPAddr) Addr() netip.Addr {
+	return ia.a
+}
+
+// String implements fmt.Stringer.
+// It returns the value as a string.
+func (ia IPAddr) String() string {
+	return ia.a.String()
+}
+
+// Value implements driver.Valuer.
+// It returns the value as a string for IPStorageText
+// and as a 4/16-byte []byte for IPStorageBinary.
+func (ia IPAddr) Value() (driver.Value, error) {
+	switch ia.storage {
+	case IPStorageText:
+		return ia.String(), nil
+	case IPStorageBinary:
+		return ia.a.AsSlice(), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage: %d", ia.storage)
+	}
+}
+
+// Scan implements sql.Scanner.
+// It accepts a string in text form, or a []byte in the form of the storage:
+// a 4/16-byte binary form for IPStorageBinary and the text form otherwise.
+// A string sets the storage to IPStorageText.
+func (ia *IPAddr) Scan(src any) error {
+	if src == nil {
+		return errors.New("invalid source: nil")
+	}
+
+	switch v := src.(type) {
+	case string:
+		if err := ia.setString(v); err != nil {
+			return fmt.Errorf("invalid source: %w", err)
+		}
+
+		ia.storage = IPStorageText
+	case []byte:
+		if ia.storage == IPStorageBinary {
+			if err := ia.setBytes(v); err != nil {
+				return fmt.Errorf("invalid source: %w", err)
+			}
+
+			return nil
+		}
+
+		if err := ia.setString(string(v)); err != nil {
+			return fmt.Errorf("invalid source: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported source type: %T", src)
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the value as a string.
+func (ia IPAddr) MarshalText() ([]byte, error) {
+	return []byte(ia.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (ia *IPAddr) UnmarshalText(b []byte) error {
+	if err := ia.setString(string(b)); err != nil {
+		return fmt.Errorf("invalid text: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns the value as a JSON string.
+func (ia IPAddr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ia.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a JSON string.
+func (ia *IPAddr) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 {
+		return errors.New("invalid json value: empty")
+	}
+	if string(b) == "null" {
+		return errors.New("invalid json value: null")
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	if err := ia.setString(s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,385 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestIPAddr(t *testing.T) {
+	var ia sqlutil.IPAddr
+	require.Implements(t, (*fmt.Stringer)(nil), &ia)
+	require.Implements(t, (*driver.Valuer)(nil), &ia)
+	require.Implements(t, (*sql.Scanner)(nil), &ia)
+	require.Implements(t, (*encoding.TextMarshaler)(nil), &ia)
+	require.Implements(t, (*encoding.TextUnmarshaler)(nil), &ia)
+	require.Implements(t, (*json.Marshaler)(nil), &ia)
+	require.Implements(t, (*json.Unmarshaler)(nil), &ia)
+}
+
+func TestNewIPAddr(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   netip.Addr
+			want string
+		}{
+			{
+				"zero value",
+				netip.Addr{},
+				"invalid netip.Addr: zero value",
+			},
+			{
+				"zone",
+				netip.MustParseAddr("fe80::1%eth0"),
+				"invalid netip.Addr: zone not supported",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.NewIPAddr(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   netip.Addr
+			want string
+		}{
+			{
+				"ipv4",
+				netip.MustParseAddr("192.0.2.1"),
+				"192.0.2.1",
+			},
+			{
+				"ipv6",
+				netip.MustParseAddr("2001:db8::1"),
+				"2001:db8::1",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				ia, err := sqlutil.NewIPAddr(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, ia.String())
+				require.Equal(t, tc.in, ia.Addr())
+			})
+		}
+	})
+}
+
+func TestNewIPAddrFromString(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"empty",
+				"",
+				"invalid ip address string: empty",
+			},
+			{
+				"invalid",
+				"192.0.2",
+				"invalid ip address string",
+			},
+			{
+				"prefix",
+				"192.0.2.1/24",
+				"invalid ip address string: prefix must be full-length",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.NewIPAddrFromString(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"ipv4",
+				"192.0.2.1",
+				"192.0.2.1",
+			},
+			{
+				"ipv4: inet",
+				"192.0.2.1/32",
+				"192.0.2.1",
+			},
+			{
+				"ipv6: inet",
+				"2001:db8::1/128",
+				"2001:db8::1",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				ia, err := sqlutil.NewIPAddrFromString(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, ia.String())
+			})
+		}
+	})
+}
+
+func TestIPAddr_Value(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   sqlutil.IPAddr
+			want driver.Value
+		}{
+			{
+				"text",
+				sqlutil.MustNewIPAddrFromString("192.0.2.1"),
+				"192.0.2.1",
+			},
+			{
+				"binary: ipv4",
+				sqlutil.MustNewIPAddrFromString("192.0.2.1").WithStorage(sqlutil.IPStorageBinary),
+				[]byte{192, 0, 2, 1},
+			},
+			{
+				"binary: ipv6",
+				sqlutil.MustNewIPAddrFromString("2001:db8::1").WithStorage(sqlutil.IPStorageBinary),
+				[]byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				v, err := tc.in.Value()
+				require.NoError(t, err)
+				require.Equal(t, tc.want, v)
+			})
+		}
+	})
+}
+
+func TestIPAddr_Scan(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"nil",
+				nil,
+				"invalid source: nil",
+			},
+			{
+				"int64",
+				int64(1),
+				"unsupported source type: int64",
+			},
+			{
+				"string: empty",
+				"",
+				"invalid source: invalid ip address string: empty",
+			},
+			{
+				"[]byte: invalid length",
+				[]byte{192, 0, 2},
+				"invalid source: invalid ip address string",
+			},
+			{
+				"[]byte: binary in text storage",
+				[]byte{192, 0, 2, 1},
+				"invalid source: invalid ip address string",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var ia sqlutil.IPAddr
+				err := ia.Scan(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name        string
+			storage     sqlutil.IPStorage
+			in          any
+			want        string
+			wantStorage sqlutil.IPStorage
+		}{
+			{
+				"string",
+				sqlutil.IPStorageText,
+				"192.0.2.1/32",
+				"192.0.2.1",
+				sqlutil.IPStorageText,
+			},
+			{
+				"string: binary storage",
+				sqlutil.IPStorageBinary,
+				"192.0.2.1",
+				"192.0.2.1",
+				sqlutil.IPStorageText,
+			},
+			{
+				"[]byte: text",
+				sqlutil.IPStorageText,
+				[]byte("2001:db8::1"),
+				"2001:db8::1",
+				sqlutil.IPStorageText,
+			},
+			{
+				"[]byte: text of 4 bytes",
+				sqlutil.IPStorageText,
+				[]byte("1::1"),
+				"1::1",
+				sqlutil.IPStorageText,
+			},
+			{
+				"[]byte: text of 16 bytes",
+				sqlutil.IPStorageText,
+				[]byte("2001:db8:85a3::8"),
+				"2001:db8:85a3::8",
+				sqlutil.IPStorageText,
+			},
+			{
+				"[]byte: ipv4",
+				sqlutil.IPStorageBinary,
+				[]byte{192, 0, 2, 1},
+				"192.0.2.1",
+				sqlutil.IPStorageBinary,
+			},
+			{
+				"[]byte: ipv6",
+				sqlutil.IPStorageBinary,
+				[]byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+				"2001:db8::1",
+				sqlutil.IPStorageBinary,
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				ia := sqlutil.IPAddr{}.WithStorage(tc.storage)
+				err := ia.Scan(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, ia.String())
+				require.Equal(t, tc.wantStorage, ia.Storage())
+			})
+		}
+	})
+
+	t.Run("failure: binary storage", func(t *testing.T) {
+		ia := sqlutil.IPAddr{}.WithStorage(sqlutil.IPStorageBinary)
+		err := ia.Scan([]byte("192.0.2.1"))
+		require.ErrorContains(t, err, "invalid source: invalid ip address bytes: length must be 4 or 16: 9")
+	})
+
+	t.Run("success: binary storage", func(t *testing.T) {
+		v, err := sqlutil.MustNewIPAddrFromString("49.58.58.49").WithStorage(sqlutil.IPStorageBinary).Value()
+		require.NoError(t, err)
+
+		ia := sqlutil.IPAddr{}.WithStorage(sqlutil.IPStorageBinary)
+		err = ia.Scan(v)
+		require.NoError(t, err)
+		require.Equal(t, "49.58.58.49", ia.String())
+		require.Equal(t, sqlutil.IPStorageBinary, ia.Storage())
+	})
+}
+
+func TestIPAddr_MarshalText(t *testing.T) {
+	b, err := sqlutil.MustNewIPAddrFromString("2001:db8::1").MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, []byte("2001:db8::1"), b)
+}
+
+func TestIPAddr_UnmarshalText(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		var ia sqlutil.IPAddr
+		err := ia.UnmarshalText([]byte{})
+		require.ErrorContains(t, err, "invalid text: invalid ip address string: empty")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var ia sqlutil.IPAddr
+		err := ia.UnmarshalText([]byte("192.0.2.1"))
+		require.NoError(t, err)
+		require.Equal(t, "192.0.2.1", ia.String())
+	})
+}
+
+func TestIPAddr_MarshalJSON(t *testing.T) {
+	b, err := sqlutil.MustNewIPAddrFromString("192.0.2.1").MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`"192.0.2.1"`), b)
+}
+
+func TestIPAddr_UnmarshalJSON(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   []byte
+			want string
+		}{
+			{
+				"empty",
+				[]byte{},
+				"invalid json value: empty",
+			},
+			{
+				"null",
+				[]byte(`null`),
+				"invalid json value: null",
+			},
+			{
+				"number",
+				[]byte(`1`),
+				"invalid json string",
+			},
+			{
+				"string: empty",
+				[]byte(`""`),
+				"invalid json string: invalid ip address string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var ia sqlutil.IPAddr
+				err := ia.UnmarshalJSON(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var ia sqlutil.IPAddr
+		err := ia.UnmarshalJSON([]byte(`"192.0.2.1"`))
+		require.NoError(t, err)
+		require.Equal(t, "192.0.2.1", ia.String())
+	})
+}
@@ -0,0 +1,231 @@
+package sqlutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// IPPrefix represents an IP network in CIDR notation.
+// The host bits must be zero.
+type IPPrefix struct {
+	p       netip.Prefix
+	storage IPStorage
+}
+
+// NewIPPrefix returns a new IPPrefix.
+func NewIPPrefix(p netip.Prefix) (IPPrefix, error) {
+	var ip IPPrefix
+	if err := ip.setPrefix(p); err != nil {
+		return IPPrefix{}, err
+	}
+
+	return ip, nil
+}
+
+// MustNewIPPrefix panics if the input is invalid.
+func MustNewIPPrefix(p netip.Prefix) IPPrefix {
+	ip, err := NewIPPrefix(p)
+	if err != nil {
+		panic(err)
+	}
+
+	return ip
+}
+
+func (ip *IPPrefix) setPrefix(p netip.Prefix) error {
+	if !p.IsValid() {
+		return errors.New("invalid netip.Prefix: zero value or invalid bits")
+	}
+	if p.Addr().Zone() != "" {
+		return errors.New("invalid netip.Prefix: zone not supported")
+	}
+	if p != p.Masked() {
+		return errors.New("invalid netip.Prefix: host bits must be zero")
+	}
+
+	ip.p = p
+
+	return nil
+}
+
+// NewIPPrefixFromString returns a new IPPrefix from a string.
+// A bare address is treated as a full-length prefix,
+// which matches the PostgreSQL inet output for a single host.
+func NewIPPrefixFromString(s string) (IPPrefix, error) {
+	var ip IPPrefix
+	if err := ip.setString(s); err != nil {
+		return IPPrefix{}, err
+	}
+
+	return ip, nil
+}
+
+// MustNewIPPrefixFromString panics if the input is invalid.
+func MustNewIPPrefixFromString(s string) IPPrefix {
+	ip, err := NewIPPrefixFromString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return ip
+}
+
+func (ip *IPPrefix) setString(s string) error {
+	if len(s) == 0 {
+		return errors.New("invalid ip prefix string: empty")
+	}
+
+	var p netip.Prefix
+	{
+		if strings.Contains(s, "/") {
+			var err error
+			if p, err = netip.ParsePrefix(s); err != nil {
+				return fmt.Errorf("invalid ip prefix string: %w", err)
+			}
+		} else {
+			a, err := netip.ParseAddr(s)
+			if err != nil {
+				return fmt.Errorf("invalid ip prefix string: %w", err)
+			}
+
+			p = netip.PrefixFrom(a, a.BitLen())
+		}
+	}
+
+	return ip.setPrefix(p)
+}
+
+func (ip *IPPrefix) setBytes(b []byte) error {
+	if len(b) != net.IPv4len+1 && len(b) != net.IPv6len+1 {
+		return fmt.Errorf("invalid ip prefix bytes: length must be 5 or 17: %d", len(b))
+	}
+
+	a, _ := netip.AddrFromSlice(b[:len(b)-1])
+
+	return ip.setPrefix(netip.PrefixFrom(a, int(b[len(b)-1])))
+}
+
+// WithStorage returns a copy of the value with the given storage.
+func (ip IPPrefix) WithStorage(storage IPStorage) IPPrefix {
+	ip.storage = storage
+
+	return ip
+}
+
+// Storage returns the storage used by Value.
+func (ip IPPrefix) Storage() IPStorage {
+	return ip.storage
+}
+
+// Prefix returns the underlying netip.Prefix.
+func (ip IPPrefix) Prefix() netip.Prefix {
+	return ip.p
+}
+
+// Contains reports whether the network includes the given address.
+func (ip IPPrefix) Contains(a netip.Addr) bool {
+	return ip.p.Contains(a)
+}
+
+// String implements fmt.Stringer.
+// It returns the value in CIDR notation.
+func (ip IPPrefix) String() string {
+	return ip.p.String()
+}
+
+// Value implements driver.Valuer.
+// It returns the value as a string for IPStorageText
+// and as a 4/16-byte address followed by the prefix length for IPStorageBinary.
+func (ip IPPrefix) Value() (driver.Value, error) {
+	switch ip.storage {
+	case IPStorageText:
+		return ip.String(), nil
+	case IPStorageBinary:
+		return append(ip.p.Addr().AsSlice(), byte(ip.p.Bits())), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage: %d", ip.storage)
+	}
+}
+
+// Scan implements sql.Scanner.
+// It accepts a string in text form, or a []byte in the form of the storage:
+// a 5/17-byte binary form for IPStorageBinary and the text form otherwise.
+// A string sets the storage to IPStorageText.
+func (ip *IPPrefix) Scan(src any) error {
+	if src == nil {
+		return errors.New("invalid source: nil")
+	}
+
+	switch v := src.(type) {
+	case string:
+		if err := ip.setString(v); err != nil {
+			return fmt.Errorf("invalid source: %w", err)
+		}
+
+		ip.storage = IPStorageText
+	case []byte:
+		if ip.storage == IPStorageBinary {
+			if err := ip.setBytes(v); err != nil {
+				return fmt.Errorf("invalid source: %w", err)
+			}
+
+			return nil
+		}
+
+		if err := ip.setString(string(v)); err != nil {
+			return fmt.Errorf("invalid source: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported source type: %T", src)
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the value in CIDR notation.
+func (ip IPPrefix) MarshalText() ([]byte, error) {
+	return []byte(ip.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (ip *IPPrefix) UnmarshalText(b []byte) error {
+	if err := ip.setString(string(b)); err != nil {
+		return fmt.Errorf("invalid text: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns the value as a JSON string in CIDR notation.
+func (ip IPPrefix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ip.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a JSON string.
+func (ip *IPPrefix) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 {
+		return errors.New("invalid json value: empty")
+	}
+	if string(b) == "null" {
+		return errors.New("invalid json value: null")
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	if err := ip.setString(s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	return nil
+}
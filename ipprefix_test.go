@@ -0,0 +1,337 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestIPPrefix(t *testing.T) {
+	var ip sqlutil.IPPrefix
+	require.Implements(t, (*fmt.Stringer)(nil), &ip)
+	require.Implements(t, (*driver.Valuer)(nil), &ip)
+	require.Implements(t, (*sql.Scanner)(nil), &ip)
+	require.Implements(t, (*encoding.TextMarshaler)(nil), &ip)
+	require.Implements(t, (*encoding.TextUnmarshaler)(nil), &ip)
+	require.Implements(t, (*json.Marshaler)(nil), &ip)
+	require.Implements(t, (*json.Unmarshaler)(nil), &ip)
+}
+
+func TestNewIPPrefix(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   netip.Prefix
+			want string
+		}{
+			{
+				"zero value",
+				netip.Prefix{},
+				"invalid netip.Prefix: zero value or invalid bits",
+			},
+			{
+				"host bits",
+				netip.MustParsePrefix("192.0.2.1/24"),
+				"invalid netip.Prefix: host bits must be zero",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.NewIPPrefix(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		p := netip.MustParsePrefix("192.0.2.0/24")
+
+		ip, err := sqlutil.NewIPPrefix(p)
+		require.NoError(t, err)
+		require.Equal(t, "192.0.2.0/24", ip.String())
+		require.Equal(t, p, ip.Prefix())
+		require.True(t, ip.Contains(netip.MustParseAddr("192.0.2.1")))
+		require.False(t, ip.Contains(netip.MustParseAddr("192.0.3.1")))
+	})
+}
+
+func TestNewIPPrefixFromString(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"empty",
+				"",
+				"invalid ip prefix string: empty",
+			},
+			{
+				"invalid bits",
+				"192.0.2.0/33",
+				"invalid ip prefix string",
+			},
+			{
+				"host bits",
+				"192.0.2.1/24",
+				"invalid netip.Prefix: host bits must be zero",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.NewIPPrefixFromString(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"ipv4",
+				"192.0.2.0/24",
+				"192.0.2.0/24",
+			},
+			{
+				"ipv4: bare address",
+				"192.0.2.1",
+				"192.0.2.1/32",
+			},
+			{
+				"ipv6",
+				"2001:db8::/32",
+				"2001:db8::/32",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				ip, err := sqlutil.NewIPPrefixFromString(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, ip.String())
+			})
+		}
+	})
+}
+
+func TestIPPrefix_Value(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   sqlutil.IPPrefix
+			want driver.Value
+		}{
+			{
+				"text",
+				sqlutil.MustNewIPPrefixFromString("192.0.2.0/24"),
+				"192.0.2.0/24",
+			},
+			{
+				"binary",
+				sqlutil.MustNewIPPrefixFromString("192.0.2.0/24").WithStorage(sqlutil.IPStorageBinary),
+				[]byte{192, 0, 2, 0, 24},
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				v, err := tc.in.Value()
+				require.NoError(t, err)
+				require.Equal(t, tc.want, v)
+			})
+		}
+	})
+}
+
+func TestIPPrefix_Scan(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name    string
+			storage sqlutil.IPStorage
+			in      any
+			want    string
+		}{
+			{
+				"nil",
+				sqlutil.IPStorageText,
+				nil,
+				"invalid source: nil",
+			},
+			{
+				"int64",
+				sqlutil.IPStorageText,
+				int64(1),
+				"unsupported source type: int64",
+			},
+			{
+				"[]byte: invalid bits",
+				sqlutil.IPStorageBinary,
+				[]byte{192, 0, 2, 0, 33},
+				"invalid source: invalid netip.Prefix: zero value or invalid bits",
+			},
+			{
+				"[]byte: binary in text storage",
+				sqlutil.IPStorageText,
+				[]byte{192, 0, 2, 0, 24},
+				"invalid source: invalid ip prefix string",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				ip := sqlutil.IPPrefix{}.WithStorage(tc.storage)
+				err := ip.Scan(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name        string
+			storage     sqlutil.IPStorage
+			in          any
+			want        string
+			wantStorage sqlutil.IPStorage
+		}{
+			{
+				"string",
+				sqlutil.IPStorageText,
+				"192.0.2.0/24",
+				"192.0.2.0/24",
+				sqlutil.IPStorageText,
+			},
+			{
+				"[]byte: text",
+				sqlutil.IPStorageText,
+				[]byte("2001:db8::/32"),
+				"2001:db8::/32",
+				sqlutil.IPStorageText,
+			},
+			{
+				"[]byte: text of 5 bytes",
+				sqlutil.IPStorageText,
+				[]byte("::/16"),
+				"::/16",
+				sqlutil.IPStorageText,
+			},
+			{
+				"[]byte: binary",
+				sqlutil.IPStorageBinary,
+				[]byte{192, 0, 2, 0, 24},
+				"192.0.2.0/24",
+				sqlutil.IPStorageBinary,
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				ip := sqlutil.IPPrefix{}.WithStorage(tc.storage)
+				err := ip.Scan(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, ip.String())
+				require.Equal(t, tc.wantStorage, ip.Storage())
+			})
+		}
+	})
+
+	t.Run("failure: binary storage", func(t *testing.T) {
+		ip := sqlutil.IPPrefix{}.WithStorage(sqlutil.IPStorageBinary)
+		err := ip.Scan([]byte("192.0.2.0/24"))
+		require.ErrorContains(t, err, "invalid source: invalid ip prefix bytes: length must be 5 or 17: 12")
+	})
+
+	t.Run("success: binary storage", func(t *testing.T) {
+		v, err := sqlutil.MustNewIPPrefixFromString("192.0.2.0/24").WithStorage(sqlutil.IPStorageBinary).Value()
+		require.NoError(t, err)
+
+		ip := sqlutil.IPPrefix{}.WithStorage(sqlutil.IPStorageBinary)
+		err = ip.Scan(v)
+		require.NoError(t, err)
+		require.Equal(t, "192.0.2.0/24", ip.String())
+		require.Equal(t, sqlutil.IPStorageBinary, ip.Storage())
+	})
+}
+
+func TestIPPrefix_MarshalText(t *testing.T) {
+	b, err := sqlutil.MustNewIPPrefixFromString("192.0.2.0/24").MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, []byte("192.0.2.0/24"), b)
+}
+
+func TestIPPrefix_UnmarshalText(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		var ip sqlutil.IPPrefix
+		err := ip.UnmarshalText([]byte{})
+		require.ErrorContains(t, err, "invalid text: invalid ip prefix string: empty")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var ip sqlutil.IPPrefix
+		err := ip.UnmarshalText([]byte("192.0.2.0/24"))
+		require.NoError(t, err)
+		require.Equal(t, "192.0.2.0/24", ip.String())
+	})
+}
+
+func TestIPPrefix_MarshalJSON(t *testing.T) {
+	b, err := sqlutil.MustNewIPPrefixFromString("192.0.2.0/24").MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`"192.0.2.0/24"`), b)
+}
+
+func TestIPPrefix_UnmarshalJSON(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   []byte
+			want string
+		}{
+			{
+				"empty",
+				[]byte{},
+				"invalid json value: empty",
+			},
+			{
+				"null",
+				[]byte(`null`),
+				"invalid json value: null",
+			},
+			{
+				"string: empty",
+				[]byte(`""`),
+				"invalid json string: invalid ip prefix string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var ip sqlutil.IPPrefix
+				err := ip.UnmarshalJSON(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var ip sqlutil.IPPrefix
+		err := ip.UnmarshalJSON([]byte(`"192.0.2.0/24"`))
+		require.NoError(t, err)
+		require.Equal(t, "192.0.2.0/24", ip.String())
+	})
+}
@@ -0,0 +1,248 @@
+//go:build go1.27
+
+package sqlutil
+
+import (
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// MarshalJSONTo implements json.MarshalerTo.
+// It writes the value as a JSON string.
+func (hu HTTPURL) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(hu.String()))
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+// It accepts a JSON string.
+func (hu *HTTPURL) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONStringFrom(dec, hu.setString)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+// It writes the value as a JSON string.
+func (ut UTCTime) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(ut.String()))
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+// It accepts a JSON string.
+func (ut *UTCTime) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONStringFrom(dec, ut.setString)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+// It writes the value as a JSON string.
+func (d Date) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(d.String()))
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+// It accepts a JSON string.
+func (d *Date) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONStringFrom(dec, d.setString)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+// It writes the value as a JSON number of seconds.
+func (ut UnixTime) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.Int(ut.Unix()))
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+// It accepts a JSON number.
+func (ut *UnixTime) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	sec, err := unmarshalJSONInt64From(dec)
+	if err != nil {
+		return err
+	}
+
+	*ut = NewUnixTimeFromInt64(sec)
+
+	return nil
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+// It writes the value as a JSON string.
+func (ia IPAddr) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(ia.String()))
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+// It accepts a JSON string.
+func (ia *IPAddr) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONStringFrom(dec, ia.setString)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+// It writes the value as a JSON string.
+func (ip IPPrefix) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(ip.String()))
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+// It accepts a JSON string.
+func (ip *IPPrefix) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONStringFrom(dec, ip.setString)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+// It writes the value as a JSON string.
+func (e Email) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(e.String()))
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+// It accepts a JSON string.
+func (e *Email) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONStringFrom(dec, e.setString)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+// It writes the value as a JSON number,
+// or as a JSON string if Entity implements JSONStringID and returns true.
+func (id ID[Entity]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	var e Entity
+	if jsi, ok := any(e).(JSONStringID); ok && jsi.JSONStringID() {
+		return enc.WriteToken(jsontext.String(id.String()))
+	}
+
+	return enc.WriteToken(jsontext.Int(id.v))
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+// It accepts a JSON number or a JSON string.
+func (id *ID[Entity]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == '"' {
+		return unmarshalJSONStringFrom(dec, id.setString)
+	}
+
+	v, err := unmarshalJSONInt64From(dec)
+	if err != nil {
+		return err
+	}
+
+	id.v = v
+
+	return nil
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+// It writes the value as a JSON string.
+func (id StringID[Entity]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(id.String()))
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+// It accepts a JSON string.
+func (id *StringID[Entity]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONStringFrom(dec, id.setString)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+// It writes the value as a JSON array.
+func (l List[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if l.items == nil {
+		if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+			return err
+		}
+
+		return enc.WriteToken(jsontext.EndArray)
+	}
+
+	return jsonv2.MarshalEncode(enc, l.items)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+// It accepts a JSON array.
+func (l *List[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if err := readJSONNull(dec); err != nil {
+		return err
+	}
+
+	var items []T
+	if err := jsonv2.UnmarshalDecode(dec, &items); err != nil {
+		return fmt.Errorf("invalid json array: %w", err)
+	}
+
+	l.items = items
+
+	return nil
+}
+
+// readJSONNull returns an error if the next value is empty or a JSON null,
+// consuming the null so that the decoder stays in a consistent state.
+func readJSONNull(dec *jsontext.Decoder) error {
+	switch dec.PeekKind() {
+	case jsontext.KindInvalid:
+		_, err := dec.ReadToken()
+		if errors.Is(err, io.EOF) {
+			return errors.New("invalid json value: empty")
+		}
+
+		return fmt.Errorf("invalid json value: %w", err)
+	case 'n':
+		if _, err := dec.ReadToken(); err != nil {
+			return fmt.Errorf("invalid json value: %w", err)
+		}
+
+		return errors.New("invalid json value: null")
+	default:
+		return nil
+	}
+}
+
+func unmarshalJSONStringFrom(dec *jsontext.Decoder, set func(string) error) error {
+	if err := readJSONNull(dec); err != nil {
+		return err
+	}
+
+	if k := dec.PeekKind(); k != '"' {
+		if err := dec.SkipValue(); err != nil {
+			return fmt.Errorf("invalid json string: %w", err)
+		}
+
+		return fmt.Errorf("invalid json string: unexpected %s", k)
+	}
+
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	if err := set(tok.String()); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	return nil
+}
+
+func unmarshalJSONInt64From(dec *jsontext.Decoder) (int64, error) {
+	if err := readJSONNull(dec); err != nil {
+		return 0, err
+	}
+
+	if k := dec.PeekKind(); k != '0' {
+		if err := dec.SkipValue(); err != nil {
+			return 0, fmt.Errorf("invalid json number: %w", err)
+		}
+
+		return 0, fmt.Errorf("invalid json number: unexpected %s", k)
+	}
+
+	v, err := dec.ReadValue()
+	if err != nil {
+		return 0, fmt.Errorf("invalid json number: %w", err)
+	}
+
+	n, err := strconv.ParseInt(string(v), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid json number: %w", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,310 @@
+//go:build go1.27
+
+package sqlutil_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestJSONV2(t *testing.T) {
+	var (
+		hu   sqlutil.HTTPURL
+		ut   sqlutil.UTCTime
+		d    sqlutil.Date
+		unix sqlutil.UnixTime
+		ia   sqlutil.IPAddr
+		ip   sqlutil.IPPrefix
+		e    sqlutil.Email
+		id   sqlutil.ID[idTestUser]
+		sid  sqlutil.StringID[idTestUser]
+		l    sqlutil.List[int64]
+	)
+
+	for _, v := range []any{&hu, &ut, &d, &unix, &ia, &ip, &e, &id, &sid, &l} {
+		require.Implements(t, (*jsonv2.MarshalerTo)(nil), v)
+		require.Implements(t, (*jsonv2.UnmarshalerFrom)(nil), v)
+	}
+}
+
+func TestHTTPURL_MarshalJSONTo(t *testing.T) {
+	hu := sqlutil.MustNewHTTPURLFromString("https://example.com/path?q=1")
+
+	b, err := jsonv2.Marshal(hu)
+	require.NoError(t, err)
+
+	want, err := hu.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, want, b)
+}
+
+func TestHTTPURL_UnmarshalJSONFrom(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"empty",
+				"",
+				"invalid json value: empty",
+			},
+			{
+				"null",
+				`null`,
+				"invalid json value: null",
+			},
+			{
+				"bool",
+				`true`,
+				"invalid json string: unexpected true",
+			},
+			{
+				"string: empty",
+				`""`,
+				"invalid json string: invalid url string: empty",
+			},
+			{
+				"string: invalid scheme",
+				`"ftp://example.com"`,
+				"invalid json string: invalid url.URL: invalid scheme",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var hu sqlutil.HTTPURL
+				err := hu.UnmarshalJSONFrom(jsontext.NewDecoder(bytes.NewReader([]byte(tc.in))))
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("failure: unmarshal", func(t *testing.T) {
+		var hu sqlutil.HTTPURL
+		err := jsonv2.Unmarshal([]byte(`null`), &hu)
+		require.ErrorContains(t, err, "invalid json value: null")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var v struct {
+			URL  sqlutil.HTTPURL `json:"url"`
+			Next sqlutil.HTTPURL `json:"next"`
+		}
+		err := jsonv2.Unmarshal([]byte(`{"url":"https://example.com","next":"http://example.com/2"}`), &v)
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com", v.URL.String())
+		require.Equal(t, "http://example.com/2", v.Next.String())
+	})
+}
+
+func TestJSONV2_RoundTrip(t *testing.T) {
+	tcs := []struct {
+		name string
+		in   any
+		new  func() any
+		want string
+	}{
+		{
+			"UTCTime",
+			sqlutil.NewUTCTime(time.Date(2025, 1, 2, 3, 4, 5, 6000, time.UTC)),
+			func() any { return new(sqlutil.UTCTime) },
+			`"2025-01-02T03:04:05.000006Z"`,
+		},
+		{
+			"Date",
+			sqlutil.NewDate(2025, time.January, 2),
+			func() any { return new(sqlutil.Date) },
+			`"2025-01-02"`,
+		},
+		{
+			"UnixTime",
+			sqlutil.NewUnixTimeFromInt64(1735787045),
+			func() any { return new(sqlutil.UnixTime) },
+			`1735787045`,
+		},
+		{
+			"IPAddr",
+			sqlutil.MustNewIPAddrFromString("192.0.2.1"),
+			func() any { return new(sqlutil.IPAddr) },
+			`"192.0.2.1"`,
+		},
+		{
+			"IPPrefix",
+			sqlutil.MustNewIPPrefixFromString("2001:db8::/32"),
+			func() any { return new(sqlutil.IPPrefix) },
+			`"2001:db8::/32"`,
+		},
+		{
+			"Email",
+			sqlutil.MustNewEmail("user@example.com"),
+			func() any { return new(sqlutil.Email) },
+			`"user@example.com"`,
+		},
+		{
+			"ID",
+			sqlutil.NewID[idTestUser](42),
+			func() any { return new(sqlutil.ID[idTestUser]) },
+			`42`,
+		},
+		{
+			"ID: json string",
+			sqlutil.NewID[idTestJSUser](42),
+			func() any { return new(sqlutil.ID[idTestJSUser]) },
+			`"42"`,
+		},
+		{
+			"StringID",
+			sqlutil.MustNewStringID[idTestUser]("abc"),
+			func() any { return new(sqlutil.StringID[idTestUser]) },
+			`"abc"`,
+		},
+		{
+			"List",
+			sqlutil.NewList([]int64{1, 2, 3}, sqlutil.DialectPostgreSQL),
+			func() any { return new(sqlutil.List[int64]) },
+			`[1,2,3]`,
+		},
+		{
+			"List: nil",
+			sqlutil.NewList[int64](nil, sqlutil.DialectPostgreSQL),
+			func() any { return new(sqlutil.List[int64]) },
+			`[]`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := jsonv2.Marshal(tc.in)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(b))
+
+			v1, err := json.Marshal(tc.in)
+			require.NoError(t, err)
+			require.Equal(t, string(v1), string(b))
+
+			got := tc.new()
+			require.NoError(t, jsonv2.Unmarshal(b, got))
+
+			b, err = jsonv2.Marshal(got)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(b))
+
+			err = jsonv2.Unmarshal([]byte(`null`), tc.new())
+			require.ErrorContains(t, err, "invalid json value: null")
+		})
+	}
+}
+
+func TestJSONV2_UnmarshalJSONFrom(t *testing.T) {
+	tcs := []struct {
+		name string
+		in   string
+		new  func() jsonv2.UnmarshalerFrom
+		want string
+	}{
+		{
+			"UnixTime: string",
+			`"1735787045"`,
+			func() jsonv2.UnmarshalerFrom { return new(sqlutil.UnixTime) },
+			"invalid json number: unexpected string",
+		},
+		{
+			"UnixTime: fraction",
+			`1.5`,
+			func() jsonv2.UnmarshalerFrom { return new(sqlutil.UnixTime) },
+			"invalid json number",
+		},
+		{
+			"ID: bool",
+			`true`,
+			func() jsonv2.UnmarshalerFrom { return new(sqlutil.ID[idTestUser]) },
+			"invalid json number: unexpected true",
+		},
+		{
+			"ID: string: invalid",
+			`"abc"`,
+			func() jsonv2.UnmarshalerFrom { return new(sqlutil.ID[idTestUser]) },
+			"invalid json string",
+		},
+		{
+			"Date: invalid",
+			`"2025-13-01"`,
+			func() jsonv2.UnmarshalerFrom { return new(sqlutil.Date) },
+			"invalid json string",
+		},
+		{
+			"List: object",
+			`{}`,
+			func() jsonv2.UnmarshalerFrom { return new(sqlutil.List[int64]) },
+			"invalid json array",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.new().UnmarshalJSONFrom(jsontext.NewDecoder(bytes.NewReader([]byte(tc.in))))
+			require.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func BenchmarkHTTPURL_MarshalJSON(b *testing.B) {
+	hu := sqlutil.MustNewHTTPURLFromString("https://example.com/path?q=1")
+
+	b.Run("v1", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := hu.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("v2", func(b *testing.B) {
+		enc := jsontext.NewEncoder(io.Discard)
+
+		for b.Loop() {
+			if err := hu.MarshalJSONTo(enc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkHTTPURL_UnmarshalJSON(b *testing.B) {
+	in := []byte(`"https://example.com/path?q=1"`)
+
+	b.Run("v1", func(b *testing.B) {
+		for b.Loop() {
+			var hu sqlutil.HTTPURL
+			if err := hu.UnmarshalJSON(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("v2", func(b *testing.B) {
+		var r bytes.Reader
+		dec := jsontext.NewDecoder(&r)
+
+		for b.Loop() {
+			r.Reset(in)
+			dec.Reset(&r)
+
+			var hu sqlutil.HTTPURL
+			if err := hu.UnmarshalJSONFrom(dec); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
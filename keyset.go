@@ -0,0 +1,237 @@
+package sqlutil
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidCursor is returned when a cursor token is malformed or has been tampered with.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// KeysetOrder is a column by which a keyset paginated query is ordered.
+type KeysetOrder struct {
+	Column string
+	Desc   bool
+}
+
+// Cursor points to the row from which the next page starts (exclusive).
+// If Backward is true, the page precedes the row instead.
+type Cursor[K any] struct {
+	Key      K
+	Backward bool
+}
+
+// Keyset builds keyset (cursor) paginated queries ordered by a fixed list of columns
+// and encodes cursors into signed tokens.
+//
+// K is a struct with a field for each order column, mapped by the `db` tag as in ScanOne,
+// holding the values of the row a cursor points to.
+// The order columns must not be NULL, and the last one must be unique (e.g. the primary key)
+// to break ties.
+type Keyset[K any] struct {
+	dialect Dialect
+	orders  []KeysetOrder
+	indexes [][]int
+	secret  []byte
+}
+
+// NewKeyset returns a new Keyset.
+// secret is the key to sign cursor tokens with HMAC-SHA256.
+func NewKeyset[K any](dialect Dialect, secret []byte, orders ...KeysetOrder) (*Keyset[K], error) {
+	if err := dialect.validate(); err != nil {
+		return nil, err
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("invalid keyset: empty secret")
+	}
+	if len(orders) == 0 {
+		return nil, errors.New("invalid keyset: no order columns")
+	}
+
+	typ := reflect.TypeFor[K]()
+	if !isStructType(typ) {
+		return nil, fmt.Errorf("unsupported type: %s", typ)
+	}
+
+	meta, err := structMetaOf(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([][]int, len(orders))
+	for i, o := range orders {
+		fi, ok := meta.lookup(o.Column)
+		if !ok {
+			return nil, fmt.Errorf("invalid keyset: %s has no field for column: %s", typ, o.Column)
+		}
+
+		indexes[i] = meta.fields[fi].index
+	}
+
+	return &Keyset[K]{
+		dialect: dialect,
+		orders:  append([]KeysetOrder(nil), orders...),
+		indexes: indexes,
+		secret:  append([]byte(nil), secret...),
+	}, nil
+}
+
+// OrderBy returns the list of the ORDER BY clause, e.g. `title ASC, id DESC`.
+// If backward is true, each direction is reversed to fetch a preceding page,
+// whose rows must then be reversed by the caller.
+func (ks *Keyset[K]) OrderBy(backward bool) string {
+	var sb strings.Builder
+	for i, o := range ks.orders {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(ks.dialect.QuoteIdentifier(o.Column))
+		if o.Desc != backward {
+			sb.WriteString(" DESC")
+		} else {
+			sb.WriteString(" ASC")
+		}
+	}
+
+	return sb.String()
+}
+
+// Where returns the predicate of the WHERE clause that selects the rows after the cursor
+// in the order of OrderBy(c.Backward), and its arguments.
+// On PostgreSQL, placeholders are numbered from n.
+//
+// If all the columns are ordered in the same direction,
+// the predicate is a row comparison such as `(title, id) > (?, ?)`;
+// otherwise, it is expanded such as `((title > ?) OR (title = ? AND id < ?))`.
+func (ks *Keyset[K]) Where(c Cursor[K], n int) (string, []any) {
+	rv := reflect.ValueOf(&c.Key).Elem()
+
+	values := make([]any, len(ks.indexes))
+	for i, index := range ks.indexes {
+		if fv, err := rv.FieldByIndexErr(index); err == nil {
+			values[i] = fv.Interface()
+		}
+	}
+
+	op := func(o KeysetOrder) string {
+		if o.Desc != c.Backward {
+			return "<"
+		}
+
+		return ">"
+	}
+
+	var (
+		sb   strings.Builder
+		args []any
+	)
+	placeholder := func(v any) string {
+		args = append(args, v)
+
+		return ks.dialect.Placeholder(n + len(args) - 1)
+	}
+
+	uniform := true
+	for _, o := range ks.orders[1:] {
+		if o.Desc != ks.orders[0].Desc {
+			uniform = false
+
+			break
+		}
+	}
+
+	if uniform {
+		cols := make([]string, len(ks.orders))
+		phs := make([]string, len(ks.orders))
+		for i, o := range ks.orders {
+			cols[i] = ks.dialect.QuoteIdentifier(o.Column)
+			phs[i] = placeholder(values[i])
+		}
+
+		if len(ks.orders) == 1 {
+			return cols[0] + " " + op(ks.orders[0]) + " " + phs[0], args
+		}
+
+		return "(" + strings.Join(cols, ", ") + ") " + op(ks.orders[0]) + " (" + strings.Join(phs, ", ") + ")", args
+	}
+
+	sb.WriteByte('(')
+	for i, o := range ks.orders {
+		if i > 0 {
+			sb.WriteString(" OR ")
+		}
+
+		sb.WriteByte('(')
+		for j := range i {
+			sb.WriteString(ks.dialect.QuoteIdentifier(ks.orders[j].Column) + " = " + placeholder(values[j]) + " AND ")
+		}
+		sb.WriteString(ks.dialect.QuoteIdentifier(o.Column) + " " + op(o) + " " + placeholder(values[i]))
+		sb.WriteByte(')')
+	}
+	sb.WriteByte(')')
+
+	return sb.String(), args
+}
+
+type cursorPayload[K any] struct {
+	Key      K    `json:"k"`
+	Backward bool `json:"b,omitempty"`
+}
+
+// Encode encodes the cursor into an opaque token signed with the secret.
+// The key is encoded as JSON, so its fields must round-trip through encoding/json.
+func (ks *Keyset[K]) Encode(c Cursor[K]) (string, error) {
+	payload, err := json.Marshal(cursorPayload[K]{
+		Key:      c.Key,
+		Backward: c.Backward,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(append(payload, ks.sign(payload)...)), nil
+}
+
+// Decode decodes a token returned by Encode.
+// It returns ErrInvalidCursor if the token is malformed or the signature does not match,
+// including when the token was encoded by a Keyset with a different secret or order.
+func (ks *Keyset[K]) Decode(token string) (Cursor[K], error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor[K]{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if len(b) < sha256.Size {
+		return Cursor[K]{}, fmt.Errorf("%w: too short", ErrInvalidCursor)
+	}
+
+	payload, sig := b[:len(b)-sha256.Size], b[len(b)-sha256.Size:]
+	if !hmac.Equal(sig, ks.sign(payload)) {
+		return Cursor[K]{}, fmt.Errorf("%w: signature mismatch", ErrInvalidCursor)
+	}
+
+	var p cursorPayload[K]
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return Cursor[K]{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return Cursor[K]{
+		Key:      p.Key,
+		Backward: p.Backward,
+	}, nil
+}
+
+// sign returns the signature of the payload, bound to the order of the keyset.
+func (ks *Keyset[K]) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, ks.secret)
+	mac.Write([]byte(ks.OrderBy(false)))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,323 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type keysetTestKey struct {
+	IsCompleted bool   `db:"is_completed" json:"is_completed"`
+	Title       string `db:"title" json:"title"`
+	ID          int64  `db:"id" json:"id"`
+}
+
+var keysetTestSecret = []byte("secret")
+
+func TestNewKeyset(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name   string
+			secret []byte
+			orders []sqlutil.KeysetOrder
+			want   string
+		}{
+			{
+				"empty secret",
+				nil,
+				[]sqlutil.KeysetOrder{{Column: "id"}},
+				"invalid keyset: empty secret",
+			},
+			{
+				"no order columns",
+				keysetTestSecret,
+				nil,
+				"invalid keyset: no order columns",
+			},
+			{
+				"no field for column",
+				keysetTestSecret,
+				[]sqlutil.KeysetOrder{{Column: "url"}},
+				"invalid keyset: sqlutil_test.keysetTestKey has no field for column: url",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.NewKeyset[keysetTestKey](sqlutil.DialectMySQL, tc.secret, tc.orders...)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+}
+
+func TestKeyset_OrderBy(t *testing.T) {
+	ks, err := sqlutil.NewKeyset[keysetTestKey](sqlutil.DialectPostgreSQL, keysetTestSecret,
+		sqlutil.KeysetOrder{Column: "title", Desc: true},
+		sqlutil.KeysetOrder{Column: "id"},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, `"title" DESC, "id" ASC`, ks.OrderBy(false))
+	require.Equal(t, `"title" ASC, "id" DESC`, ks.OrderBy(true))
+}
+
+func TestKeyset_Where(t *testing.T) {
+	key := keysetTestKey{
+		Title: "task1",
+		ID:    1,
+	}
+
+	tcs := []struct {
+		name     string
+		dialect  sqlutil.Dialect
+		orders   []sqlutil.KeysetOrder
+		backward bool
+		n        int
+		want     string
+		wantArgs []any
+	}{
+		{
+			"single column",
+			sqlutil.DialectMySQL,
+			[]sqlutil.KeysetOrder{{Column: "id"}},
+			false,
+			1,
+			"`id` > ?",
+			[]any{int64(1)},
+		},
+		{
+			"uniform",
+			sqlutil.DialectPostgreSQL,
+			[]sqlutil.KeysetOrder{{Column: "title"}, {Column: "id"}},
+			false,
+			3,
+			`("title", "id") > ($3, $4)`,
+			[]any{"task1", int64(1)},
+		},
+		{
+			"uniform: backward",
+			sqlutil.DialectPostgreSQL,
+			[]sqlutil.KeysetOrder{{Column: "title", Desc: true}, {Column: "id", Desc: true}},
+			true,
+			1,
+			`("title", "id") > ($1, $2)`,
+			[]any{"task1", int64(1)},
+		},
+		{
+			"mixed",
+			sqlutil.DialectPostgreSQL,
+			[]sqlutil.KeysetOrder{{Column: "is_completed"}, {Column: "title", Desc: true}, {Column: "id"}},
+			false,
+			1,
+			`(("is_completed" > $1) OR ("is_completed" = $2 AND "title" < $3) OR ("is_completed" = $4 AND "title" = $5 AND "id" > $6))`,
+			[]any{false, false, "task1", false, "task1", int64(1)},
+		},
+		{
+			"mixed: backward",
+			sqlutil.DialectMySQL,
+			[]sqlutil.KeysetOrder{{Column: "title", Desc: true}, {Column: "id"}},
+			true,
+			1,
+			"((`title` > ?) OR (`title` = ? AND `id` < ?))",
+			[]any{"task1", "task1", int64(1)},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ks, err := sqlutil.NewKeyset[keysetTestKey](tc.dialect, keysetTestSecret, tc.orders...)
+			require.NoError(t, err)
+
+			where, args := ks.Where(sqlutil.Cursor[keysetTestKey]{
+				Key:      key,
+				Backward: tc.backward,
+			}, tc.n)
+			require.Equal(t, tc.want, where)
+			require.Equal(t, tc.wantArgs, args)
+		})
+	}
+}
+
+func TestKeyset_Decode(t *testing.T) {
+	orders := []sqlutil.KeysetOrder{{Column: "title"}, {Column: "id"}}
+
+	ks, err := sqlutil.NewKeyset[keysetTestKey](sqlutil.DialectMySQL, keysetTestSecret, orders...)
+	require.NoError(t, err)
+
+	c := sqlutil.Cursor[keysetTestKey]{
+		Key: keysetTestKey{
+			Title: "task1",
+			ID:    1,
+		},
+		Backward: true,
+	}
+
+	token, err := ks.Encode(c)
+	require.NoError(t, err)
+
+	t.Run("failure", func(t *testing.T) {
+		ksOtherSecret, err := sqlutil.NewKeyset[keysetTestKey](sqlutil.DialectMySQL, []byte("other"), orders...)
+		require.NoError(t, err)
+
+		ksOtherOrder, err := sqlutil.NewKeyset[keysetTestKey](sqlutil.DialectMySQL, keysetTestSecret,
+			sqlutil.KeysetOrder{Column: "title", Desc: true},
+			sqlutil.KeysetOrder{Column: "id"},
+		)
+		require.NoError(t, err)
+
+		tampered := []byte(token)
+		tampered[0] ^= 1
+
+		tcs := []struct {
+			name  string
+			ks    *sqlutil.Keyset[keysetTestKey]
+			token string
+			want  string
+		}{
+			{
+				"invalid base64",
+				ks,
+				"!",
+				"invalid cursor: illegal base64 data",
+			},
+			{
+				"too short",
+				ks,
+				"e30",
+				"invalid cursor: too short",
+			},
+			{
+				"tampered",
+				ks,
+				string(tampered),
+				"invalid cursor",
+			},
+			{
+				"other secret",
+				ksOtherSecret,
+				token,
+				"invalid cursor: signature mismatch",
+			},
+			{
+				"other order",
+				ksOtherOrder,
+				token,
+				"invalid cursor: signature mismatch",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := tc.ks.Decode(tc.token)
+				require.ErrorIs(t, err, sqlutil.ErrInvalidCursor)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		decoded, err := ks.Decode(token)
+		require.NoError(t, err)
+		require.Equal(t, c, decoded)
+	})
+}
+
+func TestKeyset(t *testing.T) {
+	tcs := []struct {
+		name    string
+		db      *sql.DB
+		dialect sqlutil.Dialect
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			sqlutil.DialectMySQL,
+		},
+		{
+			"postgresql",
+			psqlDB,
+			sqlutil.DialectPostgreSQL,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			ctx := t.Context()
+
+			rows := make([][]any, 0, 8)
+			for id := 3; id <= 10; id++ {
+				rows = append(rows, []any{id, fmt.Sprintf("task%d", id%3), fmt.Sprintf("http://m0t0k1ch1.com/task/%d", id)})
+			}
+			_, err := sqlutil.BulkInsert(ctx, tc.db, tc.dialect, tc.dialect.Identifier("task"), []string{"id", "title", "url"}, rows)
+			require.NoError(t, err)
+
+			ks, err := sqlutil.NewKeyset[keysetTestKey](tc.dialect, keysetTestSecret,
+				sqlutil.KeysetOrder{Column: "title", Desc: true},
+				sqlutil.KeysetOrder{Column: "id"},
+			)
+			require.NoError(t, err)
+
+			page := func(t *testing.T, token string) []keysetTestKey {
+				t.Helper()
+
+				var (
+					c    sqlutil.Cursor[keysetTestKey]
+					q    = `SELECT is_completed, title, id FROM task`
+					args []any
+				)
+				if token != "" {
+					var err error
+					c, err = ks.Decode(token)
+					require.NoError(t, err)
+
+					var where string
+					where, args = ks.Where(c, 1)
+					q += " WHERE " + where
+				}
+				q += " ORDER BY " + ks.OrderBy(c.Backward) + " LIMIT 4"
+
+				keys, err := sqlutil.QueryAll[keysetTestKey](ctx, tc.db, q, args...)
+				require.NoError(t, err)
+
+				if c.Backward {
+					slices.Reverse(keys)
+				}
+
+				return keys
+			}
+
+			ids := func(keys []keysetTestKey) []int64 {
+				ids := make([]int64, len(keys))
+				for i, k := range keys {
+					ids[i] = k.ID
+				}
+
+				return ids
+			}
+
+			// ordered by title DESC, id ASC:
+			// task2 (2, 5, 8), task1 (1, 4, 7, 10), task0 (3, 6, 9)
+			first := page(t, "")
+			require.Equal(t, []int64{2, 5, 8, 1}, ids(first))
+
+			next, err := ks.Encode(sqlutil.Cursor[keysetTestKey]{Key: first[len(first)-1]})
+			require.NoError(t, err)
+
+			second := page(t, next)
+			require.Equal(t, []int64{4, 7, 10, 3}, ids(second))
+
+			prev, err := ks.Encode(sqlutil.Cursor[keysetTestKey]{Key: second[0], Backward: true})
+			require.NoError(t, err)
+
+			require.Equal(t, ids(first), ids(page(t, prev)))
+		})
+	}
+}
@@ -0,0 +1,392 @@
+package sqlutil
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// List represents a list of values stored in a single column.
+// It is stored as a native array (e.g. text[]) on PostgreSQL and as a JSON array on MySQL.
+// A List with no dialect (e.g. the zero value or one decoded from JSON) is stored as a JSON array;
+// use WithDialect to store it in a PostgreSQL array column.
+// Each element is encoded with driver.DefaultParameterConverter (which honors driver.Valuer)
+// and decoded with sql.Scanner if *T implements it.
+type List[T any] struct {
+	items   []T
+	dialect Dialect
+}
+
+// NewList returns a new List stored in the format of the given dialect.
+func NewList[T any](items []T, dialect Dialect) List[T] {
+	return List[T]{
+		items:   slices.Clone(items),
+		dialect: dialect,
+	}
+}
+
+// Items returns a copy of the underlying items.
+func (l List[T]) Items() []T {
+	return slices.Clone(l.items)
+}
+
+// Len returns the number of items.
+func (l List[T]) Len() int {
+	return len(l.items)
+}
+
+// Dialect returns the dialect used by Value.
+func (l List[T]) Dialect() Dialect {
+	return l.dialect
+}
+
+// WithDialect returns a copy of the value with the given dialect.
+func (l List[T]) WithDialect(dialect Dialect) List[T] {
+	l.items = slices.Clone(l.items)
+	l.dialect = dialect
+
+	return l
+}
+
+// Value implements driver.Valuer.
+// It returns the value as a PostgreSQL array literal for DialectPostgreSQL
+// and as a JSON array string for DialectMySQL or no dialect.
+func (l List[T]) Value() (driver.Value, error) {
+	vals := make([]driver.Value, len(l.items))
+	for i, item := range l.items {
+		v, err := driver.DefaultParameterConverter.ConvertValue(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid element: %d: %w", i, err)
+		}
+
+		vals[i] = v
+	}
+
+	switch l.dialect {
+	case 0, DialectMySQL:
+		return formatJSONArray(vals)
+	case DialectPostgreSQL:
+		return formatPostgreSQLArray(vals)
+	default:
+		return nil, fmt.Errorf("unsupported dialect: %s", l.dialect)
+	}
+}
+
+// Scan implements sql.Scanner.
+// It accepts a string or []byte containing a PostgreSQL array literal or a JSON array.
+// The dialect is set to match the scanned format.
+func (l *List[T]) Scan(src any) error {
+	if src == nil {
+		return errors.New("invalid source: nil")
+	}
+
+	var s string
+	{
+		switch v := src.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return fmt.Errorf("unsupported source type: %T", src)
+		}
+	}
+
+	var (
+		elems   []*string
+		dialect Dialect
+	)
+	{
+		var err error
+
+		switch s = strings.TrimSpace(s); {
+		case strings.HasPrefix(s, "{"):
+			elems, err = parsePostgreSQLArray(s)
+			dialect = DialectPostgreSQL
+		case strings.HasPrefix(s, "["):
+			elems, err = parseJSONArray(s)
+			dialect = DialectMySQL
+		default:
+			err = errors.New("invalid array string: must be a postgresql array or a json array")
+		}
+		if err != nil {
+			return fmt.Errorf("invalid source: %w", err)
+		}
+	}
+
+	items := make([]T, len(elems))
+	for i, elem := range elems {
+		item, err := scanListElem[T](elem)
+		if err != nil {
+			return fmt.Errorf("invalid source: invalid element: %d: %w", i, err)
+		}
+
+		items[i] = item
+	}
+
+	l.items = items
+	l.dialect = dialect
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns the value as a JSON array.
+func (l List[T]) MarshalJSON() ([]byte, error) {
+	if l.items == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(l.items)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a JSON array.
+// The dialect is kept as is.
+func (l *List[T]) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 {
+		return errors.New("invalid json value: empty")
+	}
+	if string(b) == "null" {
+		return errors.New("invalid json value: null")
+	}
+
+	var items []T
+	if err := json.Unmarshal(b, &items); err != nil {
+		return fmt.Errorf("invalid json array: %w", err)
+	}
+
+	l.items = items
+
+	return nil
+}
+
+func scanListElem[T any](src *string) (T, error) {
+	var v T
+
+	if scanner, ok := any(&v).(sql.Scanner); ok {
+		var err error
+		if src == nil {
+			err = scanner.Scan(nil)
+		} else {
+			err = scanner.Scan(*src)
+		}
+
+		return v, err
+	}
+
+	if src == nil {
+		if reflect.TypeFor[T]().Kind() == reflect.Pointer {
+			return v, nil
+		}
+
+		return v, errors.New("null")
+	}
+
+	var n sql.Null[T]
+	if err := n.Scan(*src); err != nil {
+		return v, err
+	}
+
+	return n.V, nil
+}
+
+func formatPostgreSQLArray(vals []driver.Value) (string, error) {
+	var sb strings.Builder
+	sb.WriteByte('{')
+
+	for i, v := range vals {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+
+		switch v := v.(type) {
+		case nil:
+			sb.WriteString("NULL")
+		case int64:
+			sb.WriteString(strconv.FormatInt(v, 10))
+		case float64:
+			sb.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		case bool:
+			sb.WriteString(strconv.FormatBool(v))
+		case string:
+			writePostgreSQLArrayString(&sb, v)
+		case []byte:
+			writePostgreSQLArrayString(&sb, string(v))
+		case time.Time:
+			writePostgreSQLArrayString(&sb, v.Format(time.RFC3339Nano))
+		default:
+			return "", fmt.Errorf("invalid element: %d: unsupported type: %T", i, v)
+		}
+	}
+
+	sb.WriteByte('}')
+
+	return sb.String(), nil
+}
+
+func writePostgreSQLArrayString(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteByte('"')
+}
+
+func parsePostgreSQLArray(s string) ([]*string, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, errors.New("invalid postgresql array: must be enclosed in braces")
+	}
+
+	body := s[1 : len(s)-1]
+	if strings.TrimSpace(body) == "" {
+		return []*string{}, nil
+	}
+
+	var elems []*string
+	for i := 0; ; {
+		for i < len(body) && isPostgreSQLArraySpace(body[i]) {
+			i++
+		}
+		if i == len(body) {
+			return nil, errors.New("invalid postgresql array: missing element")
+		}
+
+		var (
+			sb     strings.Builder
+			quoted bool
+		)
+		switch body[i] {
+		case '{':
+			return nil, errors.New("invalid postgresql array: multi-dimensional arrays are not supported")
+		case '"':
+			quoted = true
+
+			for i++; ; i++ {
+				if i == len(body) {
+					return nil, errors.New("invalid postgresql array: unterminated quoted element")
+				}
+				if body[i] == '"' {
+					i++
+
+					break
+				}
+				if body[i] == '\\' {
+					i++
+					if i == len(body) {
+						return nil, errors.New("invalid postgresql array: unterminated escape")
+					}
+				}
+				sb.WriteByte(body[i])
+			}
+		default:
+			for ; i < len(body) && body[i] != ','; i++ {
+				if body[i] == '"' || body[i] == '{' || body[i] == '}' {
+					return nil, fmt.Errorf("invalid postgresql array: unexpected %q", body[i])
+				}
+				if body[i] == '\\' {
+					quoted = true
+
+					i++
+					if i == len(body) {
+						return nil, errors.New("invalid postgresql array: unterminated escape")
+					}
+				}
+				sb.WriteByte(body[i])
+			}
+		}
+
+		elem := sb.String()
+		if !quoted {
+			elem = strings.TrimRight(elem, " \t\n\r\v\f")
+		}
+
+		if !quoted && strings.EqualFold(elem, "NULL") {
+			elems = append(elems, nil)
+		} else {
+			elems = append(elems, &elem)
+		}
+
+		for i < len(body) && isPostgreSQLArraySpace(body[i]) {
+			i++
+		}
+		if i == len(body) {
+			break
+		}
+		if body[i] != ',' {
+			return nil, fmt.Errorf("invalid postgresql array: unexpected %q", body[i])
+		}
+		i++
+	}
+
+	return elems, nil
+}
+
+func isPostgreSQLArraySpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	default:
+		return false
+	}
+}
+
+func formatJSONArray(vals []driver.Value) (string, error) {
+	elems := make([]any, len(vals))
+	for i, v := range vals {
+		switch v := v.(type) {
+		case nil, int64, float64, bool, string:
+			elems[i] = v
+		case []byte:
+			elems[i] = string(v)
+		case time.Time:
+			elems[i] = v.Format(time.RFC3339Nano)
+		default:
+			return "", fmt.Errorf("invalid element: %d: unsupported type: %T", i, v)
+		}
+	}
+
+	b, err := json.Marshal(elems)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func parseJSONArray(s string) ([]*string, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal([]byte(s), &raws); err != nil {
+		return nil, fmt.Errorf("invalid json array: %w", err)
+	}
+
+	elems := make([]*string, len(raws))
+	for i, raw := range raws {
+		switch {
+		case string(raw) == "null":
+			elems[i] = nil
+		case raw[0] == '"':
+			var elem string
+			if err := json.Unmarshal(raw, &elem); err != nil {
+				return nil, fmt.Errorf("invalid json array: %w", err)
+			}
+
+			elems[i] = &elem
+		default:
+			elem := string(raw)
+			elems[i] = &elem
+		}
+	}
+
+	return elems, nil
+}
@@ -0,0 +1,418 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestList(t *testing.T) {
+	var l sqlutil.List[string]
+	require.Implements(t, (*driver.Valuer)(nil), &l)
+	require.Implements(t, (*sql.Scanner)(nil), &l)
+	require.Implements(t, (*json.Marshaler)(nil), &l)
+	require.Implements(t, (*json.Unmarshaler)(nil), &l)
+}
+
+func TestNewList(t *testing.T) {
+	t.Run("success: no aliasing", func(t *testing.T) {
+		items := []string{"a", "b"}
+		l := sqlutil.NewList(items, sqlutil.DialectPostgreSQL)
+
+		items[0] = "c"
+		require.Equal(t, []string{"a", "b"}, l.Items())
+
+		got := l.Items()
+		got[0] = "c"
+		require.Equal(t, []string{"a", "b"}, l.Items())
+	})
+}
+
+func TestList_Value(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   driver.Valuer
+			want string
+		}{
+			{
+				"unsupported dialect",
+				sqlutil.NewList([]string{"a"}, -1),
+				"unsupported dialect: Dialect(-1)",
+			},
+			{
+				"unsupported element type",
+				sqlutil.NewList([]struct{}{{}}, sqlutil.DialectPostgreSQL),
+				"invalid element: 0",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := tc.in.Value()
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		s := "b"
+
+		tcs := []struct {
+			name string
+			in   driver.Valuer
+			want driver.Value
+		}{
+			{
+				"postgresql: empty",
+				sqlutil.NewList([]string{}, sqlutil.DialectPostgreSQL),
+				`{}`,
+			},
+			{
+				"postgresql: string",
+				sqlutil.NewList([]string{"a", `"b" \c`, "NULL", ""}, sqlutil.DialectPostgreSQL),
+				`{"a","\"b\" \\c","NULL",""}`,
+			},
+			{
+				"postgresql: int64",
+				sqlutil.NewList([]int64{1, -2}, sqlutil.DialectPostgreSQL),
+				`{1,-2}`,
+			},
+			{
+				"postgresql: pointer",
+				sqlutil.NewList([]*string{nil, &s}, sqlutil.DialectPostgreSQL),
+				`{NULL,"b"}`,
+			},
+			{
+				"postgresql: HTTPURL",
+				sqlutil.NewList([]sqlutil.HTTPURL{sqlutil.MustNewHTTPURLFromString("https://m0t0k1ch1.com")}, sqlutil.DialectPostgreSQL),
+				`{"https://m0t0k1ch1.com"}`,
+			},
+			{
+				"mysql: empty",
+				sqlutil.NewList([]string(nil), sqlutil.DialectMySQL),
+				`[]`,
+			},
+			{
+				"mysql: string",
+				sqlutil.NewList([]string{"a", `"b"`}, sqlutil.DialectMySQL),
+				`["a","\"b\""]`,
+			},
+			{
+				"mysql: int64",
+				sqlutil.NewList([]int64{1, -2}, sqlutil.DialectMySQL),
+				`[1,-2]`,
+			},
+			{
+				"mysql: pointer",
+				sqlutil.NewList([]*string{nil, &s}, sqlutil.DialectMySQL),
+				`[null,"b"]`,
+			},
+			{
+				"no dialect",
+				sqlutil.NewList([]string{"a"}, 0),
+				`["a"]`,
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				v, err := tc.in.Value()
+				require.NoError(t, err)
+				require.Equal(t, tc.want, v)
+			})
+		}
+	})
+}
+
+func TestList_Scan(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"nil",
+				nil,
+				"invalid source: nil",
+			},
+			{
+				"int64",
+				int64(1),
+				"unsupported source type: int64",
+			},
+			{
+				"string: unknown format",
+				"a,b",
+				"invalid source: invalid array string",
+			},
+			{
+				"postgresql: unterminated",
+				`{"a}`,
+				"invalid source: invalid postgresql array: unterminated quoted element",
+			},
+			{
+				"postgresql: multi-dimensional",
+				`{{1,2},{3,4}}`,
+				"invalid source: invalid postgresql array: multi-dimensional arrays are not supported",
+			},
+			{
+				"postgresql: missing element",
+				`{a,}`,
+				"invalid source: invalid postgresql array: missing element",
+			},
+			{
+				"postgresql: null element",
+				`{a,NULL}`,
+				"invalid source: invalid element: 1: null",
+			},
+			{
+				"json: invalid",
+				`[a]`,
+				"invalid source: invalid json array",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var l sqlutil.List[string]
+				err := l.Scan(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success: string", func(t *testing.T) {
+		tcs := []struct {
+			name        string
+			in          any
+			want        []string
+			wantDialect sqlutil.Dialect
+		}{
+			{
+				"postgresql: empty",
+				`{}`,
+				[]string{},
+				sqlutil.DialectPostgreSQL,
+			},
+			{
+				"postgresql",
+				[]byte(`{a, "b c" ,"\"d\"",e\,f,"NULL",""}`),
+				[]string{"a", "b c", `"d"`, "e,f", "NULL", ""},
+				sqlutil.DialectPostgreSQL,
+			},
+			{
+				"json",
+				`["a", "b c", "\"d\""]`,
+				[]string{"a", "b c", `"d"`},
+				sqlutil.DialectMySQL,
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var l sqlutil.List[string]
+				err := l.Scan(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, l.Items())
+				require.Equal(t, tc.wantDialect, l.Dialect())
+			})
+		}
+	})
+
+	t.Run("success: int64", func(t *testing.T) {
+		for _, in := range []string{`{1,-2}`, `[1,-2]`} {
+			var l sqlutil.List[int64]
+			err := l.Scan(in)
+			require.NoError(t, err)
+			require.Equal(t, []int64{1, -2}, l.Items())
+		}
+	})
+
+	t.Run("success: pointer", func(t *testing.T) {
+		for _, in := range []string{`{NULL,b}`, `[null,"b"]`} {
+			var l sqlutil.List[*string]
+			err := l.Scan(in)
+			require.NoError(t, err)
+			require.Equal(t, 2, l.Len())
+			require.Nil(t, l.Items()[0])
+			require.Equal(t, "b", *l.Items()[1])
+		}
+	})
+
+	t.Run("success: sql.Scanner", func(t *testing.T) {
+		for _, in := range []string{`{https://m0t0k1ch1.com}`, `["https://m0t0k1ch1.com"]`} {
+			var l sqlutil.List[sqlutil.HTTPURL]
+			err := l.Scan(in)
+			require.NoError(t, err)
+			require.Equal(t, []sqlutil.HTTPURL{sqlutil.MustNewHTTPURLFromString("https://m0t0k1ch1.com")}, l.Items())
+		}
+	})
+
+	t.Run("success: sql.Scanner: null element", func(t *testing.T) {
+		var l sqlutil.List[sql.NullString]
+		err := l.Scan(`{NULL,a}`)
+		require.NoError(t, err)
+		require.Equal(t, []sql.NullString{{}, {String: "a", Valid: true}}, l.Items())
+	})
+}
+
+func TestList_JSON(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		var l sqlutil.List[string]
+		require.ErrorContains(t, l.UnmarshalJSON([]byte(`null`)), "invalid json value: null")
+		require.ErrorContains(t, l.UnmarshalJSON([]byte(`{}`)), "invalid json array")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		b, err := json.Marshal(sqlutil.NewList([]string(nil), sqlutil.DialectMySQL))
+		require.NoError(t, err)
+		require.Equal(t, []byte(`[]`), b)
+
+		b, err = json.Marshal(sqlutil.NewList([]sqlutil.HTTPURL{sqlutil.MustNewHTTPURLFromString("https://m0t0k1ch1.com")}, sqlutil.DialectMySQL))
+		require.NoError(t, err)
+		require.Equal(t, []byte(`["https://m0t0k1ch1.com"]`), b)
+
+		var l sqlutil.List[sqlutil.HTTPURL]
+		require.NoError(t, json.Unmarshal(b, &l))
+		require.Equal(t, "https://m0t0k1ch1.com", l.Items()[0].String())
+	})
+
+	t.Run("success: value", func(t *testing.T) {
+		var task struct {
+			Tags sqlutil.List[string] `json:"tags"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"tags":["a","b"]}`), &task))
+
+		v, err := task.Tags.Value()
+		require.NoError(t, err)
+		require.Equal(t, `["a","b"]`, v)
+
+		l := sqlutil.NewList([]string(nil), sqlutil.DialectPostgreSQL)
+		require.NoError(t, json.Unmarshal([]byte(`["a","b"]`), &l))
+		require.Equal(t, sqlutil.DialectPostgreSQL, l.Dialect())
+
+		v, err = l.Value()
+		require.NoError(t, err)
+		require.Equal(t, `{"a","b"}`, v)
+	})
+}
+
+func TestList_DB(t *testing.T) {
+	tcs := []struct {
+		name        string
+		db          *sql.DB
+		dialect     sqlutil.Dialect
+		columnTypes [4]string
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			sqlutil.DialectMySQL,
+			[4]string{"JSON", "JSON", "JSON", "JSON"},
+		},
+		{
+			"postgresql",
+			psqlDB,
+			sqlutil.DialectPostgreSQL,
+			[4]string{"TEXT[]", "TEXT[]", "BIGINT[]", "TEXT[]"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupListTasks(t, tc.db, tc.columnTypes)
+
+			ctx := t.Context()
+
+			note := `a "quoted", {braced} \ note`
+
+			rows := []struct {
+				strs []string
+				ptrs []*string
+				ints []int64
+				urls []string
+			}{
+				{
+					[]string{"a", `"quoted"`, `back\slash`, `\"`, "NULL", "", "a,b", "{x}", " padded ", "あ"},
+					[]*string{nil, &note, nil},
+					[]int64{1, -2, math.MaxInt64, math.MinInt64},
+					[]string{"https://m0t0k1ch1.com/task/1?q=a,b", "http://m0t0k1ch1.com"},
+				},
+				{
+					[]string{},
+					[]*string{},
+					[]int64{},
+					[]string{},
+				},
+			}
+
+			for i, row := range rows {
+				urls := make([]sqlutil.HTTPURL, len(row.urls))
+				for j, u := range row.urls {
+					urls[j] = sqlutil.MustNewHTTPURLFromString(u)
+				}
+
+				_, err := tc.db.ExecContext(ctx,
+					fmt.Sprintf(`INSERT INTO list_task (id, strs, ptrs, ints, urls) VALUES (%s, %s, %s, %s, %s)`,
+						tc.dialect.Placeholder(1), tc.dialect.Placeholder(2), tc.dialect.Placeholder(3), tc.dialect.Placeholder(4), tc.dialect.Placeholder(5)),
+					i,
+					sqlutil.NewList(row.strs, tc.dialect),
+					sqlutil.NewList(row.ptrs, tc.dialect),
+					sqlutil.NewList(row.ints, tc.dialect),
+					sqlutil.NewList(urls, tc.dialect),
+				)
+				require.NoError(t, err)
+
+				var (
+					strs sqlutil.List[string]
+					ptrs sqlutil.List[*string]
+					ints sqlutil.List[int64]
+					got  sqlutil.List[sqlutil.HTTPURL]
+				)
+				err = tc.db.QueryRowContext(ctx, `SELECT strs, ptrs, ints, urls FROM list_task WHERE id = `+tc.dialect.Placeholder(1), i).
+					Scan(&strs, &ptrs, &ints, &got)
+				require.NoError(t, err)
+
+				require.Equal(t, row.strs, strs.Items())
+				require.Equal(t, row.ptrs, ptrs.Items())
+				require.Equal(t, row.ints, ints.Items())
+
+				gotURLs := make([]string, got.Len())
+				for j, u := range got.Items() {
+					gotURLs[j] = u.String()
+				}
+				require.Equal(t, row.urls, gotURLs)
+
+				require.Equal(t, tc.dialect, strs.Dialect())
+			}
+		})
+	}
+}
+
+func setupListTasks(t *testing.T, db *sql.DB, columnTypes [4]string) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE list_task (id BIGINT NOT NULL PRIMARY KEY, strs %s NOT NULL, ptrs %s NOT NULL, ints %s NOT NULL, urls %s NOT NULL)`,
+		columnTypes[0], columnTypes[1], columnTypes[2], columnTypes[3]))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		// should not use t.Context()
+		ctx := context.Background()
+
+		_, err := db.ExecContext(ctx, `DROP TABLE list_task`)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,338 @@
+package sqlutil
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BindNamed rewrites a query with named parameters (:name or @name)
+// into one with the placeholders of the dialect (see Dialect.Placeholder),
+// and returns the arguments in placeholder order.
+//
+// arg is a map with string keys, or a struct (or a pointer to one) whose fields are named
+// the same way as in ScanOne.
+// On PostgreSQL, a parameter used more than once is bound once and its placeholder is reused.
+// Positional parameters (? or $n) cannot be mixed with named ones.
+//
+// Parameters are not recognized inside string literals, quoted identifiers, comments
+// and, on PostgreSQL, dollar-quoted bodies; nor are PostgreSQL :: casts and MySQL @@ system variables.
+func BindNamed(dialect Dialect, query string, arg any) (string, []any, error) {
+	lookup, err := namedArgLookup(arg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		args       []any
+		seen       = map[string]int{}
+		positional string
+	)
+
+	q, err := rewriteParams(dialect, query, func(param string) (string, error) {
+		if param[0] != ':' && param[0] != '@' {
+			if positional == "" {
+				positional = param
+			}
+
+			return param, nil
+		}
+
+		name := param[1:]
+
+		if dialect == DialectPostgreSQL {
+			if n, ok := seen[name]; ok {
+				return dialect.Placeholder(n), nil
+			}
+		}
+
+		v, ok := lookup(name)
+		if !ok {
+			return "", fmt.Errorf("missing named parameter: %s", name)
+		}
+
+		args = append(args, v)
+		seen[name] = len(args)
+
+		return dialect.Placeholder(len(args)), nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if positional != "" && len(seen) > 0 {
+		return "", nil, fmt.Errorf("invalid query: positional parameter mixed with named ones: %s", positional)
+	}
+
+	return q, args, nil
+}
+
+// namedArgLookup returns a function that looks up a named parameter in arg.
+func namedArgLookup(arg any) (func(name string) (any, bool), error) {
+	rv := reflect.ValueOf(arg)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("invalid named argument: nil %s", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+
+	switch {
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		return func(name string) (any, bool) {
+			v := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+			if !v.IsValid() {
+				return nil, false
+			}
+
+			return v.Interface(), true
+		}, nil
+	case rv.Kind() == reflect.Struct && isStructType(rv.Type()):
+		meta, err := structMetaOf(rv.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		return func(name string) (any, bool) {
+			i, ok := meta.lookup(name)
+			if !ok {
+				return nil, false
+			}
+
+			fv, err := rv.FieldByIndexErr(meta.fields[i].index)
+			if err != nil {
+				// through a nil embedded pointer
+				return nil, true
+			}
+
+			return fv.Interface(), true
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid named argument: unsupported type: %T", arg)
+	}
+}
+
+// rewriteParams replaces each parameter in query with the result of replace,
+// skipping string literals, quoted identifiers, comments and dollar-quoted bodies of the dialect.
+// The parameter is passed as written: :name, @name, ? (MySQL) or $n (PostgreSQL).
+func rewriteParams(dialect Dialect, query string, replace func(param string) (string, error)) (string, error) {
+	if err := dialect.validate(); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(query))
+
+	for i := 0; i < len(query); {
+		c := query[i]
+
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end, err := skipQuoted(dialect, query, i)
+			if err != nil {
+				return "", err
+			}
+
+			sb.WriteString(query[i:end])
+			i = end
+
+		case c == '[' && dialect == DialectSQLite:
+			end := strings.IndexByte(query[i:], ']')
+			if end < 0 {
+				return "", fmt.Errorf("invalid query: unterminated quote: %c", c)
+			}
+
+			sb.WriteString(query[i : i+end+1])
+			i += end + 1
+
+		case c == '-' && strings.HasPrefix(query[i:], "--"),
+			c == '#' && dialect == DialectMySQL:
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query)
+			} else {
+				end += i + 1
+			}
+
+			sb.WriteString(query[i:end])
+			i = end
+
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end, err := skipBlockComment(dialect, query, i)
+			if err != nil {
+				return "", err
+			}
+
+			sb.WriteString(query[i:end])
+			i = end
+
+		case c == '?' && dialect != DialectPostgreSQL:
+			s, err := replace("?")
+			if err != nil {
+				return "", err
+			}
+
+			sb.WriteString(s)
+			i++
+
+		case c == '$' && dialect == DialectPostgreSQL && i+1 < len(query) && isDigit(query[i+1]):
+			end := i + 1
+			for end < len(query) && isDigit(query[end]) {
+				end++
+			}
+
+			s, err := replace(query[i:end])
+			if err != nil {
+				return "", err
+			}
+
+			sb.WriteString(s)
+			i = end
+
+		case c == '$' && dialect == DialectPostgreSQL:
+			end, err := skipDollarQuoted(query, i)
+			if err != nil {
+				return "", err
+			}
+
+			sb.WriteString(query[i:end])
+			i = end
+
+		case c == ':' && strings.HasPrefix(query[i:], "::"),
+			c == '@' && strings.HasPrefix(query[i:], "@@"):
+			// PostgreSQL cast, MySQL system variable (or PostgreSQL text search operator)
+			end := i + 2
+			for end < len(query) && query[end] == c {
+				end++
+			}
+
+			sb.WriteString(query[i:end])
+			i = end
+
+		case c == ':' || c == '@':
+			n := identLen(query[i+1:])
+			if n == 0 || (i > 0 && isIdentByte(query[i-1])) {
+				sb.WriteByte(c)
+				i++
+
+				continue
+			}
+
+			s, err := replace(query[i : i+1+n])
+			if err != nil {
+				return "", err
+			}
+
+			sb.WriteString(s)
+			i += 1 + n
+
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// skipQuoted returns the end of the quoted string or identifier starting at query[start].
+func skipQuoted(dialect Dialect, query string, start int) (int, error) {
+	quote := query[start]
+
+	// MySQL strings, and PostgreSQL escape strings (E'...'), treat backslash as an escape character
+	backslash := quote != '`' && dialect == DialectMySQL
+	if quote == '\'' && dialect == DialectPostgreSQL && start > 0 && (query[start-1] == 'E' || query[start-1] == 'e') &&
+		(start == 1 || !isIdentByte(query[start-2])) {
+		backslash = true
+	}
+
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			// a doubled quote is an escaped quote
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+
+				continue
+			}
+
+			return i + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid query: unterminated quote: %c", quote)
+}
+
+// skipBlockComment returns the end of the block comment starting at query[start].
+// PostgreSQL block comments nest.
+func skipBlockComment(dialect Dialect, query string, start int) (int, error) {
+	depth := 0
+	for i := start; i+1 < len(query); i++ {
+		switch {
+		case query[i] == '/' && query[i+1] == '*':
+			if depth == 0 || dialect == DialectPostgreSQL {
+				depth++
+			}
+			i++
+		case query[i] == '*' && query[i+1] == '/':
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("invalid query: unterminated block comment")
+}
+
+// skipDollarQuoted returns the end of the dollar-quoted body ($$...$$ or $tag$...$tag$) starting at query[start].
+// If query[start] does not start a dollar quote (e.g. $1), it returns start + 1.
+func skipDollarQuoted(query string, start int) (int, error) {
+	// $ may also appear in identifiers
+	if start > 0 && isIdentByte(query[start-1]) {
+		return start + 1, nil
+	}
+
+	n := identLen(query[start+1:])
+	if start+1+n >= len(query) || query[start+1+n] != '$' {
+		return start + 1, nil
+	}
+
+	tag := query[start : start+n+2]
+
+	end := strings.Index(query[start+len(tag):], tag)
+	if end < 0 {
+		return 0, fmt.Errorf("invalid query: unterminated dollar quote: %s", tag)
+	}
+
+	return start + len(tag) + end + len(tag), nil
+}
+
+// identLen returns the length of the identifier at the start of s.
+func identLen(s string) int {
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if r != '_' && !unicode.IsLetter(r) && (n == 0 || !unicode.IsDigit(r)) {
+			break
+		}
+		n += size
+	}
+
+	return n
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || isDigit(c) || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || c >= utf8.RuneSelf
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,235 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestBindNamed(t *testing.T) {
+	type taskParams struct {
+		ID    int `db:"id"`
+		Title string
+		Skip  string `db:"-"`
+	}
+
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name    string
+			dialect sqlutil.Dialect
+			query   string
+			arg     any
+			want    string
+		}{
+			{
+				"unsupported dialect",
+				0,
+				`SELECT * FROM task WHERE id = :id`,
+				map[string]any{"id": 1},
+				"unsupported dialect: Dialect(0)",
+			},
+			{
+				"unsupported argument type",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE id = :id`,
+				1,
+				"invalid named argument: unsupported type: int",
+			},
+			{
+				"nil pointer",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE id = :id`,
+				(*taskParams)(nil),
+				"invalid named argument: nil",
+			},
+			{
+				"missing named parameter: map",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE id = :id`,
+				map[string]any{},
+				"missing named parameter: id",
+			},
+			{
+				"missing named parameter: struct",
+				sqlutil.DialectPostgreSQL,
+				`SELECT * FROM task WHERE id = :skip`,
+				taskParams{},
+				"missing named parameter: skip",
+			},
+			{
+				"ambiguous column",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE id = :id`,
+				scanTestTaskAmbiguous{},
+				"invalid db tag: sqlutil_test.scanTestTaskAmbiguous.ID: duplicate column: id",
+			},
+			{
+				"unterminated quote",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE title = 'a`,
+				map[string]any{},
+				"invalid query: unterminated quote",
+			},
+			{
+				"unterminated block comment",
+				sqlutil.DialectPostgreSQL,
+				`SELECT * FROM task /* /* */`,
+				map[string]any{},
+				"invalid query: unterminated block comment",
+			},
+			{
+				"unterminated dollar quote",
+				sqlutil.DialectPostgreSQL,
+				`SELECT $tag$ :id`,
+				map[string]any{},
+				"invalid query: unterminated dollar quote: $tag$",
+			},
+			{
+				"mysql: mixed parameters",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE id = ? AND title = :title`,
+				map[string]any{"title": "task1"},
+				"invalid query: positional parameter mixed with named ones: ?",
+			},
+			{
+				"postgresql: mixed parameters",
+				sqlutil.DialectPostgreSQL,
+				`SELECT * FROM task WHERE title = @title AND id = $1`,
+				map[string]any{"title": "task1"},
+				"invalid query: positional parameter mixed with named ones: $1",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, _, err := sqlutil.BindNamed(tc.dialect, tc.query, tc.arg)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name      string
+			dialect   sqlutil.Dialect
+			query     string
+			arg       any
+			wantQuery string
+			wantArgs  []any
+		}{
+			{
+				"mysql: map",
+				sqlutil.DialectMySQL,
+				`SELECT * FROM task WHERE id = :id AND title = @title OR id = :id`,
+				map[string]any{"id": 1, "title": "task1"},
+				`SELECT * FROM task WHERE id = ? AND title = ? OR id = ?`,
+				[]any{1, "task1", 1},
+			},
+			{
+				"postgresql: map",
+				sqlutil.DialectPostgreSQL,
+				`SELECT * FROM task WHERE id = :id AND title = @title OR id = :id`,
+				map[string]any{"id": 1, "title": "task1"},
+				`SELECT * FROM task WHERE id = $1 AND title = $2 OR id = $1`,
+				[]any{1, "task1"},
+			},
+			{
+				"postgresql: struct",
+				sqlutil.DialectPostgreSQL,
+				`UPDATE task SET title = :title WHERE id = :id`,
+				&taskParams{ID: 1, Title: "task1"},
+				`UPDATE task SET title = $1 WHERE id = $2`,
+				[]any{"task1", 1},
+			},
+			{
+				"postgresql: shadowed embedded field",
+				sqlutil.DialectPostgreSQL,
+				`SELECT * FROM task WHERE id = :id`,
+				scanTestTaskShadowed{scanTestTaskBase: scanTestTaskBase{ID: 2}, ID: 1},
+				`SELECT * FROM task WHERE id = $1`,
+				[]any{int64(1)},
+			},
+			{
+				"mysql: string literals, identifiers and comments",
+				sqlutil.DialectMySQL,
+				"SELECT ':a', \"@b\", 'it''s :c', 'it\\'s :d', `:e` -- :f\n# :g\nFROM task /* :h */ WHERE id = :id",
+				map[string]any{"id": 1},
+				"SELECT ':a', \"@b\", 'it''s :c', 'it\\'s :d', `:e` -- :f\n# :g\nFROM task /* :h */ WHERE id = ?",
+				[]any{1},
+			},
+			{
+				"mysql: system variable",
+				sqlutil.DialectMySQL,
+				`SELECT @@session.time_zone, :id`,
+				map[string]any{"id": 1},
+				`SELECT @@session.time_zone, ?`,
+				[]any{1},
+			},
+			{
+				"postgresql: casts, dollar quotes and nested comments",
+				sqlutil.DialectPostgreSQL,
+				"SELECT :id::text, $$ :a $$, $fn$ :b $fn$, E'\\' :c', /* /* :d */ :e */ 1 FROM task WHERE id = :id",
+				map[string]any{"id": 1},
+				"SELECT $1::text, $$ :a $$, $fn$ :b $fn$, E'\\' :c', /* /* :d */ :e */ 1 FROM task WHERE id = $1",
+				[]any{1},
+			},
+			{
+				"postgresql: operators",
+				sqlutil.DialectPostgreSQL,
+				`SELECT * FROM task WHERE tags @> ARRAY[:tag] AND tags <@ @tags AND title = :title`,
+				map[string]any{"tag": "a", "tags": "{a}", "title": "task1"},
+				`SELECT * FROM task WHERE tags @> ARRAY[$1] AND tags <@ $2 AND title = $3`,
+				[]any{"a", "{a}", "task1"},
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				q, args, err := sqlutil.BindNamed(tc.dialect, tc.query, tc.arg)
+				require.NoError(t, err)
+				require.Equal(t, tc.wantQuery, q)
+				require.Equal(t, tc.wantArgs, args)
+			})
+		}
+	})
+
+	t.Run("success: db", func(t *testing.T) {
+		tcs := []struct {
+			name    string
+			db      *sql.DB
+			dialect sqlutil.Dialect
+		}{
+			{
+				"mysql",
+				mysqlDB,
+				sqlutil.DialectMySQL,
+			},
+			{
+				"postgresql",
+				psqlDB,
+				sqlutil.DialectPostgreSQL,
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				setupTasks(t, tc.db)
+
+				ctx := t.Context()
+
+				q, args, err := sqlutil.BindNamed(tc.dialect, `SELECT id, title, url, is_completed FROM task WHERE id = :id AND title = :title`, taskParams{
+					ID:    2,
+					Title: "task2",
+				})
+				require.NoError(t, err)
+
+				task, err := sqlutil.QueryOne[Task](ctx, tc.db, q, args...)
+				require.NoError(t, err)
+				require.Equal(t, 2, task.ID)
+			})
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package sqlutil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotFound is returned when a query that expects a row returns no rows.
+// It wraps sql.ErrNoRows.
+var ErrNotFound = fmt.Errorf("not found: %w", sql.ErrNoRows)
+
+// QueryOne runs the query and scans the first row into a T.
+// It returns ErrNotFound if there are no rows.
+// See ScanOne for how columns are mapped.
+func QueryOne[T any](ctx context.Context, querier Querier, query string, args ...any) (T, error) {
+	rows, err := queryContext(ctx, querier, query, args...)
+	if err != nil {
+		var v T
+
+		return v, err
+	}
+
+	v, err := ScanOne[T](rows)
+	if errors.Is(err, sql.ErrNoRows) {
+		return v, ErrNotFound
+	}
+
+	return v, err
+}
+
+// QueryAll runs the query and scans all rows into a slice of T.
+// It returns a nil slice if there are no rows.
+// See ScanOne for how columns are mapped.
+func QueryAll[T any](ctx context.Context, querier Querier, query string, args ...any) ([]T, error) {
+	rows, err := queryContext(ctx, querier, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScanAll[T](rows)
+}
+
+// QueryScalar runs the query and scans the single column of the first row into a T,
+// even if T is a struct (e.g. sql.NullString).
+// It returns ErrNotFound if there are no rows.
+func QueryScalar[T any](ctx context.Context, querier Querier, query string, args ...any) (v T, err error) {
+	rows, err := queryContext(ctx, querier, query, args...)
+	if err != nil {
+		return v, err
+	}
+
+	defer closeRows(rows, &err)
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return v, fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	rs, err := newScalarRowScanner[T](cols)
+	if err != nil {
+		return v, err
+	}
+
+	v, err = scanFirst(rows, rs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return v, ErrNotFound
+	}
+
+	return v, err
+}
+
+// queryContext runs the query, returning a *QueryError if it fails.
+func queryContext(ctx context.Context, querier Querier, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+
+	rows, err := querier.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, newQueryError(ctx, OpQuery, query, args, start, err)
+	}
+
+	return rows, nil
+}
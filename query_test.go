@@ -0,0 +1,168 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestQueryOne(t *testing.T) {
+	tcs := []struct {
+		name  string
+		db    *sql.DB
+		query string
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			`SELECT id, title, url, is_completed FROM task WHERE id = ?`,
+		},
+		{
+			"postgresql",
+			psqlDB,
+			`SELECT id, title, url, is_completed FROM task WHERE id = $1`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			t.Run("failure: not found", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.QueryOne[Task](ctx, tc.db, tc.query, 0)
+				require.ErrorIs(t, err, sqlutil.ErrNotFound)
+				require.ErrorIs(t, err, sql.ErrNoRows)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				task, err := sqlutil.QueryOne[Task](ctx, tc.db, tc.query, 2)
+				require.NoError(t, err)
+				require.Equal(t, Task{
+					ID:    2,
+					Title: "task2",
+					URL:   sqlutil.MustNewHTTPURLFromString("https://m0t0k1ch1.com/task/2"),
+				}, task)
+			})
+
+			t.Run("success: tx", func(t *testing.T) {
+				ctx := t.Context()
+
+				err := sqlutil.Transact(ctx, tc.db, func(ctx context.Context, tx *sql.Tx) error {
+					task, err := sqlutil.QueryOne[Task](ctx, tx, tc.query, 1)
+					require.NoError(t, err)
+					require.Equal(t, 1, task.ID)
+
+					return nil
+				})
+				require.NoError(t, err)
+			})
+		})
+	}
+}
+
+func TestQueryAll(t *testing.T) {
+	tcs := []struct {
+		name string
+		db   *sql.DB
+	}{
+		{
+			"mysql",
+			mysqlDB,
+		},
+		{
+			"postgresql",
+			psqlDB,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			t.Run("failure: invalid query", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.QueryAll[Task](ctx, tc.db, `SELECT * FROM unknown`)
+				require.Error(t, err)
+			})
+
+			t.Run("success: no rows", func(t *testing.T) {
+				ctx := t.Context()
+
+				tasks, err := sqlutil.QueryAll[Task](ctx, tc.db, `SELECT id, title, url, is_completed FROM task WHERE id = 0`)
+				require.NoError(t, err)
+				require.Empty(t, tasks)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				tasks, err := sqlutil.QueryAll[Task](ctx, tc.db, `SELECT id, title, url, is_completed FROM task ORDER BY id`)
+				require.NoError(t, err)
+				require.Len(t, tasks, 2)
+				require.Equal(t, 1, tasks[0].ID)
+				require.Equal(t, 2, tasks[1].ID)
+			})
+		})
+	}
+}
+
+func TestQueryScalar(t *testing.T) {
+	tcs := []struct {
+		name string
+		db   *sql.DB
+	}{
+		{
+			"mysql",
+			mysqlDB,
+		},
+		{
+			"postgresql",
+			psqlDB,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			t.Run("failure: not found", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.QueryScalar[string](ctx, tc.db, `SELECT title FROM task WHERE id = 0`)
+				require.ErrorIs(t, err, sqlutil.ErrNotFound)
+			})
+
+			t.Run("failure: multiple columns", func(t *testing.T) {
+				ctx := t.Context()
+
+				_, err := sqlutil.QueryScalar[string](ctx, tc.db, `SELECT id, title FROM task`)
+				require.ErrorContains(t, err, "invalid columns: string requires exactly 1 column, got 2")
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				cnt, err := sqlutil.QueryScalar[int64](ctx, tc.db, `SELECT COUNT(*) FROM task`)
+				require.NoError(t, err)
+				require.Equal(t, int64(2), cnt)
+			})
+
+			t.Run("success: struct", func(t *testing.T) {
+				ctx := t.Context()
+
+				title, err := sqlutil.QueryScalar[sql.Null[string]](ctx, tc.db, `SELECT title FROM task ORDER BY id`)
+				require.NoError(t, err)
+				require.Equal(t, sql.Null[string]{V: "task1", Valid: true}, title)
+			})
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package sqlutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Op is an operation that can fail with a QueryError.
+type Op string
+
+const (
+	// OpExec represents executing a statement.
+	OpExec Op = "exec"
+	// OpQuery represents running a query that returns rows.
+	OpQuery Op = "query"
+	// OpCommit represents committing a transaction.
+	OpCommit Op = "commit"
+)
+
+// maxQueryErrorQueryLen is the maximum length in bytes of QueryError.Query.
+const maxQueryErrorQueryLen = 200
+
+// QueryError is an error returned by a driver, annotated with the statement that caused it.
+// The values of the arguments are not recorded unless the context is marked by WithQueryArgs,
+// so as not to leak sensitive data.
+type QueryError struct {
+	Op Op
+	// Query is the query text with whitespace collapsed,
+	// truncated to 200 bytes with a trailing "...".
+	Query   string
+	NumArgs int
+	// Args is the values of the arguments, recorded only if the context is marked by WithQueryArgs.
+	Args []any
+	// Path and Line locate the statement in a SQL file, if any.
+	// Line is 0 if unknown.
+	Path    string
+	Line    int
+	Elapsed time.Duration
+	Err     error
+}
+
+type queryArgsKey struct{}
+
+// WithQueryArgs returns a copy of ctx that makes a QueryError record the values of the arguments,
+// e.g. to debug a statement with non-sensitive arguments.
+func WithQueryArgs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queryArgsKey{}, true)
+}
+
+func recordsQueryArgs(ctx context.Context) bool {
+	v, _ := ctx.Value(queryArgsKey{}).(bool)
+
+	return v
+}
+
+func newQueryError(ctx context.Context, op Op, query string, args []any, start time.Time, err error) *QueryError {
+	qerr := &QueryError{
+		Op:      op,
+		Query:   truncateQuery(query),
+		NumArgs: len(args),
+		Elapsed: time.Since(start),
+		Err:     err,
+	}
+	if recordsQueryArgs(ctx) {
+		qerr.Args = args
+	}
+
+	return qerr
+}
+
+// Error implements error.
+func (e *QueryError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString(string(e.Op))
+	if e.Path != "" {
+		sb.WriteString(" ")
+		sb.WriteString(e.Path)
+		if e.Line > 0 {
+			fmt.Fprintf(&sb, ":%d", e.Line)
+		}
+	}
+	switch {
+	case e.Query != "" && e.Args != nil:
+		fmt.Fprintf(&sb, " %q (%d args %v, %s)", e.Query, e.NumArgs, e.Args, e.Elapsed)
+	case e.Query != "":
+		fmt.Fprintf(&sb, " %q (%d args, %s)", e.Query, e.NumArgs, e.Elapsed)
+	default:
+		fmt.Fprintf(&sb, " (%s)", e.Elapsed)
+	}
+	sb.WriteString(": ")
+	sb.WriteString(e.Err.Error())
+
+	return sb.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// truncateQuery collapses whitespace in the query and truncates it to maxQueryErrorQueryLen bytes.
+func truncateQuery(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if len(query) <= maxQueryErrorQueryLen {
+		return query
+	}
+
+	n := maxQueryErrorQueryLen - len("...")
+	for n > 0 && !utf8.RuneStart(query[n]) {
+		n--
+	}
+
+	return query[:n] + "..."
+}
+
+// errorLine returns the 1-based line in the query at which err occurred, or 0 if unknown.
+// Only PostgreSQL reports the position.
+func errorLine(query string, err error) int {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Position <= 0 {
+		return 0
+	}
+
+	// Position is a 1-based index in characters
+	line, pos := 1, 1
+	for _, r := range query {
+		if pos >= int(pgErr.Position) {
+			break
+		}
+		if r == '\n' {
+			line++
+		}
+		pos++
+	}
+
+	return line
+}
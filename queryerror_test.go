@@ -0,0 +1,155 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type queryErrorTestQuerier struct {
+	err error
+}
+
+func (q queryErrorTestQuerier) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, q.err
+}
+
+func (q queryErrorTestQuerier) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return nil, q.err
+}
+
+func TestQueryError_Error(t *testing.T) {
+	errSomethingWentWrong := errors.New("something went wrong")
+
+	tcs := []struct {
+		name string
+		in   *sqlutil.QueryError
+		want string
+	}{
+		{
+			"query",
+			&sqlutil.QueryError{
+				Op:      sqlutil.OpQuery,
+				Query:   "SELECT * FROM task WHERE id = ?",
+				NumArgs: 1,
+				Elapsed: time.Millisecond,
+				Err:     errSomethingWentWrong,
+			},
+			`query "SELECT * FROM task WHERE id = ?" (1 args, 1ms): something went wrong`,
+		},
+		{
+			"args",
+			&sqlutil.QueryError{
+				Op:      sqlutil.OpQuery,
+				Query:   "SELECT * FROM task WHERE id = ?",
+				NumArgs: 1,
+				Args:    []any{1},
+				Elapsed: time.Millisecond,
+				Err:     errSomethingWentWrong,
+			},
+			`query "SELECT * FROM task WHERE id = ?" (1 args [1], 1ms): something went wrong`,
+		},
+		{
+			"file",
+			&sqlutil.QueryError{
+				Op:      sqlutil.OpExec,
+				Query:   "SELECT 1",
+				Path:    "/path/to/schema.sql",
+				Line:    3,
+				Elapsed: time.Second,
+				Err:     errSomethingWentWrong,
+			},
+			`exec /path/to/schema.sql:3 "SELECT 1" (0 args, 1s): something went wrong`,
+		},
+		{
+			"commit",
+			&sqlutil.QueryError{
+				Op:      sqlutil.OpCommit,
+				Elapsed: time.Millisecond,
+				Err:     errSomethingWentWrong,
+			},
+			`commit (1ms): something went wrong`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.in.Error())
+			require.ErrorIs(t, tc.in, errSomethingWentWrong)
+		})
+	}
+}
+
+func TestQueryError_Query(t *testing.T) {
+	errSomethingWentWrong := errors.New("something went wrong")
+
+	q := queryErrorTestQuerier{errSomethingWentWrong}
+
+	tcs := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			"whitespace",
+			"SELECT *\n  FROM task\n\tWHERE id = ?",
+			"SELECT * FROM task WHERE id = ?",
+		},
+		{
+			"truncated",
+			"SELECT '" + strings.Repeat("あ", 100) + "'",
+			"SELECT '" + strings.Repeat("あ", 63) + "...",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := sqlutil.QueryOne[int](t.Context(), q, tc.query, 1, "secret")
+
+			var qerr *sqlutil.QueryError
+			require.ErrorAs(t, err, &qerr)
+			require.Equal(t, sqlutil.OpQuery, qerr.Op)
+			require.Equal(t, tc.want, qerr.Query)
+			require.Equal(t, 2, qerr.NumArgs)
+			require.Nil(t, qerr.Args)
+			require.NotContains(t, err.Error(), "secret")
+			require.ErrorIs(t, err, errSomethingWentWrong)
+		})
+	}
+}
+
+func TestQueryError_Args(t *testing.T) {
+	errSomethingWentWrong := errors.New("something went wrong")
+
+	q := queryErrorTestQuerier{errSomethingWentWrong}
+
+	_, err := sqlutil.QueryOne[int](sqlutil.WithQueryArgs(t.Context()), q, "SELECT * FROM task WHERE id = ? AND title = ?", 1, "task1")
+
+	var qerr *sqlutil.QueryError
+	require.ErrorAs(t, err, &qerr)
+	require.Equal(t, []any{1, "task1"}, qerr.Args)
+	require.ErrorContains(t, err, "[1 task1]")
+}
+
+func TestQueryError_Exec(t *testing.T) {
+	errSomethingWentWrong := errors.New("something went wrong")
+
+	qe := queryErrorTestQuerier{errSomethingWentWrong}
+
+	_, err := sqlutil.BulkInsert(t.Context(), qe, sqlutil.DialectMySQL, sqlutil.DialectMySQL.Identifier("task"), []string{"id", "title"}, [][]any{
+		{1, "task1"},
+	})
+
+	var qerr *sqlutil.QueryError
+	require.ErrorAs(t, err, &qerr)
+	require.Equal(t, sqlutil.OpExec, qerr.Op)
+	require.Equal(t, "INSERT INTO `task` (`id`, `title`) VALUES (?, ?)", qerr.Query)
+	require.ErrorIs(t, err, errSomethingWentWrong)
+}
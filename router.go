@@ -0,0 +1,206 @@
+package sqlutil
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Balancer is a strategy to choose a replica to read from.
+type Balancer int
+
+const (
+	// BalancerRoundRobin chooses the healthy replicas in turn.
+	BalancerRoundRobin Balancer = iota
+	// BalancerLeastConnections chooses the healthy replica with the fewest connections in use.
+	BalancerLeastConnections
+)
+
+// RouterOption configures a Router.
+type RouterOption func(*routerConfig)
+
+type routerConfig struct {
+	balancer            Balancer
+	healthCheckInterval time.Duration
+}
+
+// WithBalancer sets the strategy to choose a replica. The default is BalancerRoundRobin.
+func WithBalancer(b Balancer) RouterOption {
+	return func(conf *routerConfig) {
+		conf.balancer = b
+	}
+}
+
+// WithHealthCheckInterval sets the interval at which the replicas are pinged,
+// which also bounds each ping. The default is 5 seconds, and 0 disables health checks.
+func WithHealthCheckInterval(d time.Duration) RouterOption {
+	return func(conf *routerConfig) {
+		conf.healthCheckInterval = d
+	}
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a copy of ctx that makes a Router read from the primary,
+// e.g. to read your own writes regardless of replication lag.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func usesPrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryKey{}).(bool)
+
+	return v
+}
+
+type replica struct {
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// Router splits reads and writes between a primary and its read replicas.
+// Statements executed by ExecContext and all transactions go to the primary,
+// while queries go to a healthy replica, or to the primary if there are none
+// or the context is marked by WithPrimary.
+// Queries that write, such as INSERT ... RETURNING or SELECT ... FOR UPDATE,
+// must be run with a context marked by WithPrimary.
+//
+// Replicas that fail a health ping are ejected until they succeed again.
+// The Router does not own the databases; Close stops the health checks without closing them.
+type Router struct {
+	primary  *sql.DB
+	replicas []*replica
+	conf     routerConfig
+	next     atomic.Uint64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRouter returns a new Router and starts the health checks of the replicas.
+func NewRouter(primary *sql.DB, replicas []*sql.DB, opts ...RouterOption) *Router {
+	conf := routerConfig{
+		healthCheckInterval: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	r := &Router{
+		primary:  primary,
+		replicas: make([]*replica, len(replicas)),
+		conf:     conf,
+	}
+	for i, db := range replicas {
+		r.replicas[i] = &replica{
+			db: db,
+		}
+		r.replicas[i].healthy.Store(true)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	if conf.healthCheckInterval > 0 && len(replicas) > 0 {
+		r.wg.Go(func() {
+			ticker := time.NewTicker(conf.healthCheckInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					r.CheckHealth(ctx)
+				}
+			}
+		})
+	}
+
+	return r
+}
+
+// Close stops the health checks.
+func (r *Router) Close() error {
+	r.cancel()
+	r.wg.Wait()
+
+	return nil
+}
+
+// CheckHealth pings all the replicas concurrently,
+// ejecting those that fail and restoring those that succeed.
+func (r *Router) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, rep := range r.replicas {
+		wg.Go(func() {
+			ctx := ctx
+			if r.conf.healthCheckInterval > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, r.conf.healthCheckInterval)
+				defer cancel()
+			}
+
+			rep.healthy.Store(rep.db.PingContext(ctx) == nil)
+		})
+	}
+	wg.Wait()
+}
+
+// Primary returns the primary database.
+func (r *Router) Primary() *sql.DB {
+	return r.primary
+}
+
+// Reader returns the database to read from in the context.
+func (r *Router) Reader(ctx context.Context) *sql.DB {
+	if usesPrimary(ctx) {
+		return r.primary
+	}
+
+	healthy := make([]*replica, 0, len(r.replicas))
+	for _, rep := range r.replicas {
+		if rep.healthy.Load() {
+			healthy = append(healthy, rep)
+		}
+	}
+	if len(healthy) == 0 {
+		return r.primary
+	}
+
+	switch r.conf.balancer {
+	case BalancerLeastConnections:
+		least := healthy[0]
+		for _, rep := range healthy[1:] {
+			if rep.db.Stats().InUse < least.db.Stats().InUse {
+				least = rep
+			}
+		}
+
+		return least.db
+	default:
+		return healthy[(r.next.Add(1)-1)%uint64(len(healthy))].db
+	}
+}
+
+// BeginTx implements TxStarter, starting the transaction on the primary.
+func (r *Router) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.primary.BeginTx(ctx, opts)
+}
+
+// ExecContext implements QueryExecutor, executing the statement on the primary.
+func (r *Router) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return r.primary.ExecContext(ctx, query, args...)
+}
+
+// QueryContext implements Querier, running the query on the database returned by Reader.
+func (r *Router) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return r.Reader(ctx).QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs the query on the database returned by Reader.
+func (r *Router) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return r.Reader(ctx).QueryRowContext(ctx, query, args...)
+}
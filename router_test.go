@@ -0,0 +1,201 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type routerTestConnector struct {
+	name string
+	down atomic.Bool
+}
+
+func (c *routerTestConnector) Connect(context.Context) (driver.Conn, error) {
+	if c.down.Load() {
+		return nil, errors.New(c.name + ": down")
+	}
+
+	return routerTestConn{c}, nil
+}
+
+func (c *routerTestConnector) Driver() driver.Driver {
+	return dialectTestDriver{}
+}
+
+type routerTestConn struct {
+	*routerTestConnector
+}
+
+func (c routerTestConn) Ping(context.Context) error {
+	if c.down.Load() {
+		return driver.ErrBadConn
+	}
+
+	return nil
+}
+
+func (c routerTestConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New(c.name + ": not implemented")
+}
+
+func (c routerTestConn) Close() error {
+	return nil
+}
+
+func (c routerTestConn) Begin() (driver.Tx, error) {
+	return nil, errors.New(c.name + ": not implemented")
+}
+
+func newRouterTestDB(t *testing.T, name string) (*sql.DB, *routerTestConnector) {
+	t.Helper()
+
+	c := &routerTestConnector{
+		name: name,
+	}
+
+	db := sql.OpenDB(c)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return db, c
+}
+
+func TestRouter(t *testing.T) {
+	t.Run("primary", func(t *testing.T) {
+		ctx := t.Context()
+
+		primary, _ := newRouterTestDB(t, "primary")
+		replica, _ := newRouterTestDB(t, "replica")
+
+		r := sqlutil.NewRouter(primary, []*sql.DB{replica}, sqlutil.WithHealthCheckInterval(0))
+		defer r.Close()
+
+		require.Same(t, primary, r.Primary())
+
+		_, err := r.ExecContext(ctx, `UPDATE task SET is_completed = true`)
+		require.ErrorContains(t, err, "primary: not implemented")
+
+		_, err = r.BeginTx(ctx, nil)
+		require.ErrorContains(t, err, "primary: not implemented")
+
+		err = sqlutil.Transact(ctx, r, func(ctx context.Context, tx *sql.Tx) error {
+			return nil
+		})
+		require.ErrorContains(t, err, "primary: not implemented")
+
+		_, err = r.QueryContext(ctx, `SELECT 1`)
+		require.ErrorContains(t, err, "replica: not implemented")
+
+		_, err = r.QueryContext(sqlutil.WithPrimary(ctx), `SELECT 1`)
+		require.ErrorContains(t, err, "primary: not implemented")
+
+		err = sqlutil.Insert(ctx, r, sqlutil.DialectPostgreSQL, sqlutil.DialectPostgreSQL.Identifier("auto_task"), &writeTestTask{
+			Title: "task1",
+		})
+		require.ErrorContains(t, err, "primary: not implemented")
+	})
+
+	t.Run("no replicas", func(t *testing.T) {
+		primary, _ := newRouterTestDB(t, "primary")
+
+		r := sqlutil.NewRouter(primary, nil)
+		defer r.Close()
+
+		require.Same(t, primary, r.Reader(t.Context()))
+	})
+
+	t.Run("round robin", func(t *testing.T) {
+		ctx := t.Context()
+
+		primary, _ := newRouterTestDB(t, "primary")
+		replica1, _ := newRouterTestDB(t, "replica1")
+		replica2, _ := newRouterTestDB(t, "replica2")
+
+		r := sqlutil.NewRouter(primary, []*sql.DB{replica1, replica2}, sqlutil.WithHealthCheckInterval(0))
+		defer r.Close()
+
+		require.Same(t, replica1, r.Reader(ctx))
+		require.Same(t, replica2, r.Reader(ctx))
+		require.Same(t, replica1, r.Reader(ctx))
+	})
+
+	t.Run("least connections", func(t *testing.T) {
+		ctx := t.Context()
+
+		primary, _ := newRouterTestDB(t, "primary")
+		replica1, _ := newRouterTestDB(t, "replica1")
+		replica2, _ := newRouterTestDB(t, "replica2")
+
+		r := sqlutil.NewRouter(primary, []*sql.DB{replica1, replica2},
+			sqlutil.WithBalancer(sqlutil.BalancerLeastConnections),
+			sqlutil.WithHealthCheckInterval(0),
+		)
+		defer r.Close()
+
+		conn, err := replica1.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.Same(t, replica2, r.Reader(ctx))
+		require.Same(t, replica2, r.Reader(ctx))
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		ctx := t.Context()
+
+		primary, _ := newRouterTestDB(t, "primary")
+		replica1, replica1Connector := newRouterTestDB(t, "replica1")
+		replica2, replica2Connector := newRouterTestDB(t, "replica2")
+
+		r := sqlutil.NewRouter(primary, []*sql.DB{replica1, replica2}, sqlutil.WithHealthCheckInterval(0))
+		defer r.Close()
+
+		replica1Connector.down.Store(true)
+		r.CheckHealth(ctx)
+
+		require.Same(t, replica2, r.Reader(ctx))
+		require.Same(t, replica2, r.Reader(ctx))
+
+		replica2Connector.down.Store(true)
+		r.CheckHealth(ctx)
+
+		require.Same(t, primary, r.Reader(ctx))
+
+		replica1Connector.down.Store(false)
+		r.CheckHealth(ctx)
+
+		require.Same(t, replica1, r.Reader(ctx))
+	})
+
+	t.Run("health check: background", func(t *testing.T) {
+		ctx := t.Context()
+
+		primary, _ := newRouterTestDB(t, "primary")
+		replica, replicaConnector := newRouterTestDB(t, "replica")
+
+		r := sqlutil.NewRouter(primary, []*sql.DB{replica}, sqlutil.WithHealthCheckInterval(10*time.Millisecond))
+		defer r.Close()
+
+		require.Same(t, replica, r.Reader(ctx))
+
+		replicaConnector.down.Store(true)
+		require.Eventually(t, func() bool {
+			return r.Reader(ctx) == primary
+		}, time.Second, 10*time.Millisecond)
+
+		replicaConnector.down.Store(false)
+		require.Eventually(t, func() bool {
+			return r.Reader(ctx) == replica
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,437 @@
+package sqlutil
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+var (
+	// ErrUnmappedColumn is returned when a result column has no corresponding struct field.
+	ErrUnmappedColumn = errors.New("unmapped column")
+	// ErrMissingColumn is returned when a struct field has no corresponding result column
+	// and WithDisallowMissingColumns is specified.
+	ErrMissingColumn = errors.New("missing column")
+)
+
+// Rows is the subset of *sql.Rows used to scan rows.
+type Rows interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
+
+// ScanOption configures how rows are scanned.
+type ScanOption func(*scanConfig)
+
+type scanConfig struct {
+	allowUnmappedColumns   bool
+	disallowMissingColumns bool
+}
+
+// WithAllowUnmappedColumns makes result columns without a corresponding struct field discarded
+// instead of reported as ErrUnmappedColumn.
+func WithAllowUnmappedColumns() ScanOption {
+	return func(conf *scanConfig) {
+		conf.allowUnmappedColumns = true
+	}
+}
+
+// WithDisallowMissingColumns makes struct fields without a corresponding result column
+// reported as ErrMissingColumn instead of left as the zero value.
+func WithDisallowMissingColumns() ScanOption {
+	return func(conf *scanConfig) {
+		conf.disallowMissingColumns = true
+	}
+}
+
+// ScanOne scans the first row into a T and closes the rows.
+// It returns sql.ErrNoRows if there are no rows.
+//
+// If T (or the type T points to) is a struct that does not implement sql.Scanner,
+// result columns are mapped to its fields by the `db` tag,
+// falling back to the snake_case field name (e.g. IsCompleted -> is_completed).
+// Fields of embedded structs without a `db` tag are promoted, and fields tagged `db:"-"` are ignored.
+// As in Go, a field shadows a deeper one mapped to the same column; two at the same depth are an error.
+// Otherwise, the result must consist of a single column, which is scanned into the T itself.
+func ScanOne[T any](rows Rows, opts ...ScanOption) (v T, err error) {
+	defer closeRows(rows, &err)
+
+	rs, err := newRowScanner[T](rows, opts...)
+	if err != nil {
+		return v, err
+	}
+
+	return scanFirst(rows, rs)
+}
+
+// ScanAll scans all rows into a slice of T and closes the rows.
+// It returns a nil slice if there are no rows.
+// See ScanOne for how columns are mapped.
+func ScanAll[T any](rows Rows, opts ...ScanOption) (vs []T, err error) {
+	defer closeRows(rows, &err)
+
+	rs, err := newRowScanner[T](rows, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		v, err := rs.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		vs = append(vs, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return vs, nil
+}
+
+func scanFirst[T any](rows Rows, rs *rowScanner[T]) (T, error) {
+	if !rows.Next() {
+		var v T
+		if err := rows.Err(); err != nil {
+			return v, fmt.Errorf("failed to iterate rows: %w", err)
+		}
+
+		return v, sql.ErrNoRows
+	}
+
+	return rs.scan(rows)
+}
+
+func closeRows(rows Rows, err *error) {
+	if cerr := rows.Close(); cerr != nil && *err == nil {
+		*err = fmt.Errorf("failed to close rows: %w", cerr)
+	}
+}
+
+// rowScanner scans rows with a fixed set of columns into a T.
+type rowScanner[T any] struct {
+	ptr     bool
+	indexes [][]int // nil for unmapped columns; nil as a whole for a single scalar column
+}
+
+func newRowScanner[T any](rows Rows, opts ...ScanOption) (*rowScanner[T], error) {
+	var conf scanConfig
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	typ := reflect.TypeFor[T]()
+
+	rs := &rowScanner[T]{}
+	if typ.Kind() == reflect.Pointer && isStructType(typ.Elem()) {
+		rs.ptr = true
+		typ = typ.Elem()
+	}
+
+	if !isStructType(typ) {
+		if rs.ptr {
+			return nil, fmt.Errorf("unsupported type: %s", reflect.TypeFor[T]())
+		}
+
+		return newScalarRowScanner[T](cols)
+	}
+
+	meta, err := structMetaOf(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	rs.indexes = make([][]int, len(cols))
+
+	mapped := make([]bool, len(meta.fields))
+	for i, col := range cols {
+		fi, ok := meta.lookup(col)
+		if !ok {
+			if !conf.allowUnmappedColumns {
+				return nil, fmt.Errorf("%w: %s: %s", ErrUnmappedColumn, typ, col)
+			}
+
+			continue
+		}
+		if mapped[fi] {
+			return nil, fmt.Errorf("invalid columns: duplicate column: %s", col)
+		}
+
+		mapped[fi] = true
+		rs.indexes[i] = meta.fields[fi].index
+	}
+
+	if conf.disallowMissingColumns {
+		for fi, ok := range mapped {
+			if !ok {
+				return nil, fmt.Errorf("%w: %s: %s", ErrMissingColumn, typ, meta.fields[fi].name)
+			}
+		}
+	}
+
+	return rs, nil
+}
+
+// newScalarRowScanner returns a rowScanner that scans a single column into the T itself.
+func newScalarRowScanner[T any](cols []string) (*rowScanner[T], error) {
+	if len(cols) != 1 {
+		return nil, fmt.Errorf("invalid columns: %s requires exactly 1 column, got %d", reflect.TypeFor[T](), len(cols))
+	}
+
+	return &rowScanner[T]{}, nil
+}
+
+func (rs *rowScanner[T]) scan(rows Rows) (T, error) {
+	var v T
+
+	if rs.indexes == nil {
+		if err := rows.Scan(&v); err != nil {
+			return v, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		return v, nil
+	}
+
+	rv := reflect.ValueOf(&v).Elem()
+	if rs.ptr {
+		rv.Set(reflect.New(rv.Type().Elem()))
+		rv = rv.Elem()
+	}
+
+	dest := make([]any, len(rs.indexes))
+	for i, index := range rs.indexes {
+		if index == nil {
+			dest[i] = new(any)
+
+			continue
+		}
+
+		dest[i] = fieldByIndexAlloc(rv, index).Addr().Interface()
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return v, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return v, nil
+}
+
+// fieldByIndexAlloc is like reflect.Value.FieldByIndex,
+// but allocates nil pointers to embedded structs along the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+// isStructType reports whether the type is a struct to be mapped field by field,
+// as opposed to a value scanned as a whole like time.Time or a sql.Scanner.
+func isStructType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && typ != timeType && !reflect.PointerTo(typ).Implements(scannerType)
+}
+
+// fieldMeta describes a struct field mapped to a column.
+type fieldMeta struct {
+	name      string
+	index     []int
+	pk        bool
+	auto      bool
+	omitEmpty bool
+}
+
+// structMeta describes how a struct type maps to columns.
+type structMeta struct {
+	fields []fieldMeta
+	byName map[string]int
+}
+
+func (meta *structMeta) lookup(col string) (int, bool) {
+	if i, ok := meta.byName[col]; ok {
+		return i, true
+	}
+
+	i, ok := meta.byName[strings.ToLower(col)]
+
+	return i, ok
+}
+
+var structMetaCache sync.Map // map[reflect.Type]*structMeta
+
+func structMetaOf(typ reflect.Type) (*structMeta, error) {
+	if meta, ok := structMetaCache.Load(typ); ok {
+		return meta.(*structMeta), nil
+	}
+
+	meta := &structMeta{
+		byName: map[string]int{},
+	}
+	if err := meta.collect(typ, nil); err != nil {
+		return nil, err
+	}
+	if err := meta.resolve(typ); err != nil {
+		return nil, err
+	}
+
+	actual, _ := structMetaCache.LoadOrStore(typ, meta)
+
+	return actual.(*structMeta), nil
+}
+
+func (meta *structMeta) collect(typ reflect.Type, index []int) error {
+	for i := range typ.NumField() {
+		sf := typ.Field(i)
+
+		tag, hasTag := sf.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if sf.Anonymous && !hasTag {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				// a nil pointer to an unexported struct cannot be allocated
+				if !sf.IsExported() {
+					continue
+				}
+				ft = ft.Elem()
+			}
+			if isStructType(ft) {
+				if err := meta.collect(ft, fieldIndex); err != nil {
+					return err
+				}
+
+				continue
+			}
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		fm, err := parseFieldTag(sf.Name, tag)
+		if err != nil {
+			return fmt.Errorf("invalid db tag: %s.%s: %w", typ, sf.Name, err)
+		}
+		fm.index = fieldIndex
+
+		meta.fields = append(meta.fields, fm)
+	}
+
+	return nil
+}
+
+// resolve drops the fields shadowed by a shallower field mapped to the same column,
+// following the Go rule for embedded struct fields, and indexes the rest by column.
+// Fields mapped to the same column at the same depth are an error.
+func (meta *structMeta) resolve(typ reflect.Type) error {
+	depths := map[string]int{}
+	for _, fm := range meta.fields {
+		if depth, ok := depths[fm.name]; !ok || len(fm.index) < depth {
+			depths[fm.name] = len(fm.index)
+		}
+	}
+
+	fields := make([]fieldMeta, 0, len(meta.fields))
+	for _, fm := range meta.fields {
+		if len(fm.index) > depths[fm.name] {
+			continue
+		}
+		if _, ok := meta.byName[fm.name]; ok {
+			return fmt.Errorf("invalid db tag: %s.%s: duplicate column: %s", typ, typ.FieldByIndex(fm.index).Name, fm.name)
+		}
+
+		meta.byName[fm.name] = len(fields)
+		fields = append(fields, fm)
+	}
+
+	for i, fm := range fields {
+		if lower := strings.ToLower(fm.name); lower != fm.name {
+			if _, ok := meta.byName[lower]; !ok {
+				meta.byName[lower] = i
+			}
+		}
+	}
+
+	meta.fields = fields
+
+	return nil
+}
+
+// parseFieldTag parses a `db` tag of the form "name,opt1,opt2".
+// Supported options are pk, auto and omitempty.
+func parseFieldTag(fieldName, tag string) (fieldMeta, error) {
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = toSnakeCase(fieldName)
+	}
+
+	fm := fieldMeta{
+		name: name,
+	}
+
+	if opts != "" {
+		for opt := range strings.SplitSeq(opts, ",") {
+			switch opt {
+			case "pk":
+				fm.pk = true
+			case "auto":
+				fm.auto = true
+			case "omitempty":
+				fm.omitEmpty = true
+			default:
+				return fieldMeta{}, fmt.Errorf("unknown option: %s", opt)
+			}
+		}
+	}
+
+	return fm, nil
+}
+
+// toSnakeCase converts a Go identifier to snake_case,
+// keeping acronyms together (e.g. UserID -> user_id, HTTPServer -> http_server).
+func toSnakeCase(s string) string {
+	rs := []rune(s)
+
+	var sb strings.Builder
+	for i, r := range rs {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(rs[i-1]) || unicode.IsDigit(rs[i-1]) ||
+				(unicode.IsUpper(rs[i-1]) && i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,293 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type scanTestTaskBase struct {
+	ID    int    `db:"id"`
+	Title string `db:"title"`
+}
+
+type scanTestTask struct {
+	scanTestTaskBase
+	Link        *sqlutil.HTTPURL `db:"url"`
+	IsCompleted bool
+	Note        string `db:"-"`
+}
+
+type scanTestTaskPtrBase struct {
+	*Task
+	Extra string
+}
+
+type scanTestTaskShadowed struct {
+	scanTestTaskBase
+	ID int64 `db:"id"`
+}
+
+type scanTestTaskOtherBase struct {
+	ID int `db:"id"`
+}
+
+type scanTestTaskAmbiguous struct {
+	scanTestTaskBase
+	scanTestTaskOtherBase
+}
+
+func TestScanOne(t *testing.T) {
+	tcs := []struct {
+		name string
+		db   *sql.DB
+	}{
+		{
+			"mysql",
+			mysqlDB,
+		},
+		{
+			"postgresql",
+			psqlDB,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			t.Run("failure: no rows", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title, url, is_completed FROM task WHERE id = 0`)
+				require.NoError(t, err)
+
+				_, err = sqlutil.ScanOne[Task](rows)
+				require.ErrorIs(t, err, sql.ErrNoRows)
+			})
+
+			t.Run("failure: unmapped column", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title, url, is_completed, 1 AS extra FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				_, err = sqlutil.ScanOne[Task](rows)
+				require.ErrorIs(t, err, sqlutil.ErrUnmappedColumn)
+				require.ErrorContains(t, err, "extra")
+			})
+
+			t.Run("failure: missing column", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				_, err = sqlutil.ScanOne[Task](rows, sqlutil.WithDisallowMissingColumns())
+				require.ErrorIs(t, err, sqlutil.ErrMissingColumn)
+				require.ErrorContains(t, err, "url")
+			})
+
+			t.Run("failure: scalar with multiple columns", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				_, err = sqlutil.ScanOne[int](rows)
+				require.ErrorContains(t, err, "invalid columns: int requires exactly 1 column, got 2")
+			})
+
+			t.Run("failure: ambiguous column", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				_, err = sqlutil.ScanOne[scanTestTaskAmbiguous](rows)
+				require.ErrorContains(t, err, "duplicate column: id")
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title, url, is_completed FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				task, err := sqlutil.ScanOne[Task](rows)
+				require.NoError(t, err)
+				require.Equal(t, Task{
+					ID:    1,
+					Title: "task1",
+					URL:   sqlutil.MustNewHTTPURLFromString("http://m0t0k1ch1.com/task/1"),
+				}, task)
+			})
+
+			t.Run("success: embedded struct and pointer field", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title, url, is_completed FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				task, err := sqlutil.ScanOne[*scanTestTask](rows)
+				require.NoError(t, err)
+				require.Equal(t, 1, task.ID)
+				require.Equal(t, "task1", task.Title)
+				require.NotNil(t, task.Link)
+				require.Equal(t, "http://m0t0k1ch1.com/task/1", task.Link.String())
+				require.False(t, task.IsCompleted)
+			})
+
+			t.Run("success: shadowed embedded field", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				task, err := sqlutil.ScanOne[scanTestTaskShadowed](rows)
+				require.NoError(t, err)
+				require.Equal(t, int64(1), task.ID)
+				require.Zero(t, task.scanTestTaskBase.ID)
+				require.Equal(t, "task1", task.Title)
+			})
+
+			t.Run("success: embedded pointer", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title, 'x' AS extra FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				task, err := sqlutil.ScanOne[scanTestTaskPtrBase](rows)
+				require.NoError(t, err)
+				require.NotNil(t, task.Task)
+				require.Equal(t, 1, task.ID)
+				require.Equal(t, "task1", task.Title)
+				require.Equal(t, "x", task.Extra)
+			})
+
+			t.Run("success: unmapped column allowed", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, 1 AS extra FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				task, err := sqlutil.ScanOne[Task](rows, sqlutil.WithAllowUnmappedColumns())
+				require.NoError(t, err)
+				require.Equal(t, 1, task.ID)
+			})
+
+			t.Run("success: scalar", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT url FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				url, err := sqlutil.ScanOne[sqlutil.HTTPURL](rows)
+				require.NoError(t, err)
+				require.Equal(t, "http://m0t0k1ch1.com/task/1", url.String())
+			})
+		})
+	}
+}
+
+func TestScanAll(t *testing.T) {
+	tcs := []struct {
+		name string
+		db   *sql.DB
+	}{
+		{
+			"mysql",
+			mysqlDB,
+		},
+		{
+			"postgresql",
+			psqlDB,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			t.Run("failure: invalid db tag", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				_, err = sqlutil.ScanAll[struct {
+					ID int `db:"id,unknown"`
+				}](rows)
+				require.ErrorContains(t, err, "invalid db tag")
+			})
+
+			t.Run("success: no rows", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title, url, is_completed FROM task WHERE id = 0`)
+				require.NoError(t, err)
+
+				tasks, err := sqlutil.ScanAll[Task](rows)
+				require.NoError(t, err)
+				require.Nil(t, tasks)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title, url, is_completed FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				tasks, err := sqlutil.ScanAll[Task](rows)
+				require.NoError(t, err)
+				require.Equal(t, []Task{
+					{
+						ID:    1,
+						Title: "task1",
+						URL:   sqlutil.MustNewHTTPURLFromString("http://m0t0k1ch1.com/task/1"),
+					},
+					{
+						ID:    2,
+						Title: "task2",
+						URL:   sqlutil.MustNewHTTPURLFromString("https://m0t0k1ch1.com/task/2"),
+					},
+				}, tasks)
+			})
+
+			t.Run("success: scalar", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				ids, err := sqlutil.ScanAll[int64](rows)
+				require.NoError(t, err)
+				require.Equal(t, []int64{1, 2}, ids)
+			})
+		})
+	}
+}
+
+func setupTasks(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	fPath, err := filepath.Abs("./testdata/fixture.sql")
+	require.NoError(t, err)
+
+	err = sqlutil.ExecFile(ctx, db, fPath)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		// should not use t.Context()
+		ctx := context.Background()
+
+		truncateTask(t, ctx, db)
+	})
+}
@@ -0,0 +1,78 @@
+package sqlutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// errStopped is used internally to tell that the consumer of a sequence stopped iterating.
+var errStopped = errors.New("stopped")
+
+// ScanSeq returns a sequence that scans each row into a T,
+// for use with `for v, err := range ScanSeq[T](rows)`.
+// The rows are closed when the iteration finishes, including on break.
+// If an error occurs, it is yielded once with the zero T and the iteration stops.
+// The rows can be iterated only once.
+// See ScanOne for how columns are mapped.
+func ScanSeq[T any](rows Rows, opts ...ScanOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		yieldRows(context.Background(), rows, yield, opts...)
+	}
+}
+
+// QuerySeq returns a sequence that runs the query and scans each row into a T,
+// for use with `for v, err := range QuerySeq[T](ctx, db, query)`.
+// The query is run each time the sequence is iterated, and the rows are closed when the iteration finishes,
+// including on break, so that the connection can be reused (e.g. for the next query in the same *sql.Tx).
+// If an error occurs, including the cancellation of ctx, it is yielded once with the zero T and the iteration stops.
+// See ScanOne for how columns are mapped.
+func QuerySeq[T any](ctx context.Context, querier Querier, query string, args ...any) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		rows, err := queryContext(ctx, querier, query, args...)
+		if err != nil {
+			var v T
+			yield(v, err)
+
+			return
+		}
+
+		yieldRows(ctx, rows, yield)
+	}
+}
+
+func yieldRows[T any](ctx context.Context, rows Rows, yield func(T, error) bool, opts ...ScanOption) {
+	err := func() (err error) {
+		defer closeRows(rows, &err)
+
+		rs, err := newRowScanner[T](rows, opts...)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			v, err := rs.scan(rows)
+			if err != nil {
+				return err
+			}
+
+			if !yield(v, nil) {
+				return errStopped
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to iterate rows: %w", err)
+		}
+
+		return nil
+	}()
+	if err != nil && !errors.Is(err, errStopped) {
+		var v T
+		yield(v, err)
+	}
+}
@@ -0,0 +1,151 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestScanSeq(t *testing.T) {
+	tcs := []struct {
+		name string
+		db   *sql.DB
+	}{
+		{
+			"mysql",
+			mysqlDB,
+		},
+		{
+			"postgresql",
+			psqlDB,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			t.Run("failure: unmapped column", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, 1 AS extra FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				var errs []error
+				for _, err := range sqlutil.ScanSeq[Task](rows) {
+					errs = append(errs, err)
+				}
+				require.Len(t, errs, 1)
+				require.ErrorIs(t, errs[0], sqlutil.ErrUnmappedColumn)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				rows, err := tc.db.QueryContext(ctx, `SELECT id, title, url, is_completed FROM task ORDER BY id`)
+				require.NoError(t, err)
+
+				var ids []int
+				for task, err := range sqlutil.ScanSeq[Task](rows) {
+					require.NoError(t, err)
+
+					ids = append(ids, task.ID)
+				}
+				require.Equal(t, []int{1, 2}, ids)
+			})
+		})
+	}
+}
+
+func TestQuerySeq(t *testing.T) {
+	tcs := []struct {
+		name string
+		db   *sql.DB
+	}{
+		{
+			"mysql",
+			mysqlDB,
+		},
+		{
+			"postgresql",
+			psqlDB,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			query := `SELECT id, title, url, is_completed FROM task ORDER BY id`
+
+			t.Run("failure: invalid query", func(t *testing.T) {
+				ctx := t.Context()
+
+				var errs []error
+				for _, err := range sqlutil.QuerySeq[Task](ctx, tc.db, `SELECT * FROM unknown`) {
+					errs = append(errs, err)
+				}
+				require.Len(t, errs, 1)
+				require.Error(t, errs[0])
+			})
+
+			t.Run("failure: canceled", func(t *testing.T) {
+				ctx, cancel := context.WithCancel(t.Context())
+				defer cancel()
+
+				var (
+					ids  []int
+					errs []error
+				)
+				for task, err := range sqlutil.QuerySeq[Task](ctx, tc.db, query) {
+					if err != nil {
+						errs = append(errs, err)
+
+						continue
+					}
+
+					ids = append(ids, task.ID)
+					cancel()
+				}
+				require.Equal(t, []int{1}, ids)
+				require.Len(t, errs, 1)
+				require.ErrorIs(t, errs[0], context.Canceled)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				var ids []int
+				for task, err := range sqlutil.QuerySeq[Task](ctx, tc.db, query) {
+					require.NoError(t, err)
+
+					ids = append(ids, task.ID)
+				}
+				require.Equal(t, []int{1, 2}, ids)
+			})
+
+			t.Run("success: break in tx", func(t *testing.T) {
+				ctx := t.Context()
+
+				err := sqlutil.Transact(ctx, tc.db, func(ctx context.Context, tx *sql.Tx) error {
+					for task, err := range sqlutil.QuerySeq[Task](ctx, tx, query) {
+						require.NoError(t, err)
+						require.Equal(t, 1, task.ID)
+
+						break
+					}
+
+					// the rows must have been closed to run another query on the same connection
+					require.Equal(t, 2, countAllTasks(t, ctx, tx))
+
+					return nil
+				})
+				require.NoError(t, err)
+			})
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // TxStarter starts a new transaction.
@@ -19,6 +20,11 @@ type QueryExecutor interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 }
 
+// Querier executes a query that returns rows.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
 // Transact runs the given function within a transaction.
 func Transact(ctx context.Context, txStarter TxStarter, f func(context.Context, *sql.Tx) error) (err error) {
 	var tx *sql.Tx
@@ -35,8 +41,37 @@ func Transact(ctx context.Context, txStarter TxStarter, f func(context.Context,
 		} else if err != nil {
 			tx.Rollback()
 		} else {
+			start := time.Now()
 			if err = tx.Commit(); err != nil {
-				err = fmt.Errorf("failed to commit transaction: %w", err)
+				err = fmt.Errorf("failed to commit transaction: %w", newQueryError(ctx, OpCommit, "", nil, start, err))
+			}
+		}
+	}()
+
+	err = f(ctx, tx)
+
+	return
+}
+
+// TransactSavepoint runs the given function within a savepoint of the transaction,
+// rolling back to the savepoint if the function returns an error or panics,
+// so that the transaction can continue.
+func TransactSavepoint(ctx context.Context, tx *sql.Tx, dialect Dialect, name string, f func(context.Context, *sql.Tx) error) (err error) {
+	if _, err := tx.ExecContext(ctx, dialect.SavepointSQL(name)); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.ExecContext(ctx, dialect.RollbackToSavepointSQL(name))
+			panic(r)
+		} else if err != nil {
+			if _, rerr := tx.ExecContext(ctx, dialect.RollbackToSavepointSQL(name)); rerr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to roll back to savepoint: %w", rerr))
+			}
+		} else {
+			if _, err = tx.ExecContext(ctx, dialect.ReleaseSavepointSQL(name)); err != nil {
+				err = fmt.Errorf("failed to release savepoint: %w", err)
 			}
 		}
 	}()
@@ -48,6 +83,8 @@ func Transact(ctx context.Context, txStarter TxStarter, f func(context.Context,
 
 // ExecFile executes a SQL file.
 // When using github.com/go-sql-driver/mysql, ensure `multiStatements=true`.
+// If the execution fails, the returned error is a *QueryError with the path,
+// and on PostgreSQL, the line at which the error occurred.
 func ExecFile(ctx context.Context, queryExecutor QueryExecutor, path string) error {
 	if !filepath.IsAbs(path) {
 		return errors.New("path must be absolute")
@@ -58,8 +95,15 @@ func ExecFile(ctx context.Context, queryExecutor QueryExecutor, path string) err
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
-	if _, err := queryExecutor.ExecContext(ctx, string(b)); err != nil {
-		return err
+	query := string(b)
+
+	start := time.Now()
+	if _, err := queryExecutor.ExecContext(ctx, query); err != nil {
+		qerr := newQueryError(ctx, OpExec, query, nil, start, err)
+		qerr.Path = path
+		qerr.Line = errorLine(query, err)
+
+		return qerr
 	}
 
 	return nil
@@ -18,6 +18,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/m0t0k1ch1-go/sqlutil/v3"
+	"github.com/m0t0k1ch1-go/sqlutil/v3/sqlutiltest"
 )
 
 var (
@@ -314,18 +315,121 @@ func TestTransact(t *testing.T) {
 	}
 }
 
+func TestTransactSavepoint(t *testing.T) {
+	tcs := []struct {
+		name    string
+		db      *sql.DB
+		dialect sqlutil.Dialect
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			sqlutil.DialectMySQL,
+		},
+		{
+			"postgresql",
+			psqlDB,
+			sqlutil.DialectPostgreSQL,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTasks(t, tc.db)
+
+			completeTask := func(t *testing.T, ctx context.Context, tx *sql.Tx, id int) {
+				t.Helper()
+
+				_, err := tx.ExecContext(ctx, `UPDATE task SET is_completed = true WHERE id = `+tc.dialect.Placeholder(1), id)
+				require.NoError(t, err)
+			}
+
+			isCompleted := func(t *testing.T, ctx context.Context, id int) bool {
+				t.Helper()
+
+				completed, err := sqlutil.QueryScalar[bool](ctx, tc.db, `SELECT is_completed FROM task WHERE id = `+tc.dialect.Placeholder(1), id)
+				require.NoError(t, err)
+
+				return completed
+			}
+
+			t.Run("failure: rollback to savepoint on panic", func(t *testing.T) {
+				ctx := t.Context()
+
+				errPanic := errors.New("panic")
+
+				err := sqlutil.Transact(ctx, tc.db, func(ctx context.Context, tx *sql.Tx) error {
+					completeTask(t, ctx, tx, 1)
+
+					require.PanicsWithError(t, errPanic.Error(), func() {
+						sqlutil.TransactSavepoint(ctx, tx, tc.dialect, "sp1", func(ctx context.Context, tx *sql.Tx) error {
+							completeTask(t, ctx, tx, 2)
+
+							panic(errPanic)
+						})
+					})
+
+					return nil
+				})
+				require.NoError(t, err)
+
+				require.True(t, isCompleted(t, ctx, 1))
+				require.False(t, isCompleted(t, ctx, 2))
+			})
+
+			t.Run("failure: rollback to savepoint on error", func(t *testing.T) {
+				ctx := t.Context()
+
+				errSomethingWentWrong := errors.New("something went wrong")
+
+				err := sqlutil.Transact(ctx, tc.db, func(ctx context.Context, tx *sql.Tx) error {
+					err := sqlutil.TransactSavepoint(ctx, tx, tc.dialect, "sp1", func(ctx context.Context, tx *sql.Tx) error {
+						completeTask(t, ctx, tx, 2)
+
+						return errSomethingWentWrong
+					})
+					require.ErrorIs(t, err, errSomethingWentWrong)
+
+					return nil
+				})
+				require.NoError(t, err)
+
+				require.False(t, isCompleted(t, ctx, 2))
+			})
+
+			t.Run("success", func(t *testing.T) {
+				ctx := t.Context()
+
+				err := sqlutil.Transact(ctx, tc.db, func(ctx context.Context, tx *sql.Tx) error {
+					return sqlutil.TransactSavepoint(ctx, tx, tc.dialect, "sp1", func(ctx context.Context, tx *sql.Tx) error {
+						completeTask(t, ctx, tx, 2)
+
+						return nil
+					})
+				})
+				require.NoError(t, err)
+
+				require.True(t, isCompleted(t, ctx, 2))
+			})
+		})
+	}
+}
+
 func TestExecFile(t *testing.T) {
 	tcs := []struct {
-		name string
-		db   *sql.DB
+		name     string
+		db       *sql.DB
+		wantLine int
 	}{
 		{
 			"mysql",
 			mysqlDB,
+			0,
 		},
 		{
 			"postgresql",
 			psqlDB,
+			3,
 		},
 	}
 
@@ -340,6 +444,24 @@ func TestExecFile(t *testing.T) {
 				require.Zero(t, countAllTasks(t, ctx, tc.db))
 			})
 
+			t.Run("failure: query error", func(t *testing.T) {
+				ctx := t.Context()
+
+				fPath := filepath.Join(t.TempDir(), "broken.sql")
+				err := os.WriteFile(fPath, []byte("-- broken\n\nSELECT * FROM no_such_table;\n"), 0o600)
+				require.NoError(t, err)
+
+				err = sqlutil.ExecFile(ctx, tc.db, fPath)
+
+				var qerr *sqlutil.QueryError
+				require.ErrorAs(t, err, &qerr)
+				require.Equal(t, sqlutil.OpExec, qerr.Op)
+				require.Equal(t, "-- broken SELECT * FROM no_such_table;", qerr.Query)
+				require.Equal(t, fPath, qerr.Path)
+				require.Equal(t, tc.wantLine, qerr.Line)
+				require.ErrorContains(t, err, "no_such_table")
+			})
+
 			t.Run("success", func(t *testing.T) {
 				ctx := t.Context()
 
@@ -355,6 +477,81 @@ func TestExecFile(t *testing.T) {
 	}
 }
 
+func TestConformance(t *testing.T) {
+	dbs := func(mysqlColumnType, psqlColumnType string) []sqlutiltest.DB {
+		return []sqlutiltest.DB{
+			{
+				Name:       "mysql",
+				DB:         mysqlDB,
+				Dialect:    sqlutil.DialectMySQL,
+				ColumnType: mysqlColumnType,
+			},
+			{
+				Name:       "postgresql",
+				DB:         psqlDB,
+				Dialect:    sqlutil.DialectPostgreSQL,
+				ColumnType: psqlColumnType,
+			},
+		}
+	}
+
+	t.Run("HTTPURL", func(t *testing.T) {
+		sqlutiltest.Run(t, sqlutiltest.Config[sqlutil.HTTPURL]{
+			New:     sqlutil.NewHTTPURLFromString,
+			Valid:   []string{"http://m0t0k1ch1.com", "https://m0t0k1ch1.com/task/1"},
+			Invalid: []string{"", "ftp://m0t0k1ch1.com"},
+			DBs:     dbs("TEXT", "TEXT"),
+		})
+	})
+
+	t.Run("Email", func(t *testing.T) {
+		sqlutiltest.Run(t, sqlutiltest.Config[sqlutil.Email]{
+			New: func(s string) (sqlutil.Email, error) {
+				return sqlutil.NewEmail(s)
+			},
+			Valid:   []string{"m0t0k1ch1@example.com"},
+			Invalid: []string{"", "m0t0k1ch1"},
+			DBs:     dbs("VARCHAR(255)", "VARCHAR(255)"),
+		})
+	})
+
+	t.Run("IPAddr", func(t *testing.T) {
+		sqlutiltest.Run(t, sqlutiltest.Config[sqlutil.IPAddr]{
+			New:     sqlutil.NewIPAddrFromString,
+			Valid:   []string{"192.0.2.1", "2001:db8::1", "1::1", "2001:db8:85a3::8"},
+			Invalid: []string{"", "192.0.2"},
+			DBs:     dbs("VARCHAR(45)", "INET"),
+		})
+	})
+
+	t.Run("UTCTime", func(t *testing.T) {
+		sqlutiltest.Run(t, sqlutiltest.Config[sqlutil.UTCTime]{
+			New:     sqlutil.NewUTCTimeFromString,
+			Valid:   []string{"2025-01-02T03:04:05.123456Z"},
+			Invalid: []string{"", "2025-01-02"},
+			DBs:     dbs("DATETIME(6)", "TIMESTAMP"),
+		})
+	})
+
+	t.Run("Date", func(t *testing.T) {
+		sqlutiltest.Run(t, sqlutiltest.Config[sqlutil.Date]{
+			New:     sqlutil.NewDateFromString,
+			Valid:   []string{"2025-01-02"},
+			Invalid: []string{"", "0000-00-00"},
+			DBs:     dbs("DATE", "DATE"),
+		})
+	})
+
+	t.Run("UnixTime", func(t *testing.T) {
+		sqlutiltest.Run(t, sqlutiltest.Config[sqlutil.UnixTime]{
+			New:     sqlutil.NewUnixTimeFromString,
+			Valid:   []string{"1735787045"},
+			Invalid: []string{"", "a"},
+			DBs:     dbs("BIGINT", "BIGINT"),
+		})
+	})
+}
+
 func countAllTasks(t *testing.T, ctx context.Context, dbtx DBTX) (cnt int) {
 	t.Helper()
 
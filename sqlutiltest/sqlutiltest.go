@@ -0,0 +1,335 @@
+// Package sqlutiltest provides conformance tests for custom column types.
+package sqlutiltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+// Value is the set of methods a column type must implement.
+type Value interface {
+	fmt.Stringer
+	driver.Valuer
+	json.Marshaler
+}
+
+// PtrValue is the set of methods a pointer to a column type must implement.
+type PtrValue[T any] interface {
+	*T
+	sql.Scanner
+	json.Unmarshaler
+}
+
+// Config configures the conformance tests for a column type.
+type Config[T Value] struct {
+	// New returns a new value from a string, like New*FromString.
+	New func(s string) (T, error)
+	// Valid is a list of strings accepted by New.
+	Valid []string
+	// Invalid is a list of strings rejected by New.
+	Invalid []string
+	// Equal reports whether two values are equal.
+	// If nil, require.Equal is used.
+	Equal func(a, b T) bool
+	// DBs is a list of databases to round-trip values through.
+	DBs []DB
+}
+
+// DB is a database to round-trip values through.
+type DB struct {
+	// Name is the name of the subtest.
+	Name string
+	// DB is the database.
+	DB *sql.DB
+	// Dialect is the dialect of the database.
+	Dialect sqlutil.Dialect
+	// ColumnType is the column type used to store values (e.g. TEXT).
+	ColumnType string
+}
+
+// Run runs the conformance tests:
+// interface implementation, construction, String/Value/Scan/JSON/text round-trips,
+// Scan of string, []byte, nil and unsupported types, no aliasing of scanned []byte,
+// and, if configured, round-trips through real databases.
+func Run[T Value, PT PtrValue[T]](t *testing.T, conf Config[T]) {
+	t.Helper()
+
+	require.NotNil(t, conf.New, "Config.New must not be nil")
+	require.NotEmpty(t, conf.Valid, "Config.Valid must not be empty")
+
+	t.Run("interfaces", func(t *testing.T) {
+		var v T
+		require.Implements(t, (*fmt.Stringer)(nil), &v)
+		require.Implements(t, (*driver.Valuer)(nil), &v)
+		require.Implements(t, (*sql.Scanner)(nil), &v)
+		require.Implements(t, (*json.Marshaler)(nil), &v)
+		require.Implements(t, (*json.Unmarshaler)(nil), &v)
+	})
+
+	t.Run("new", func(t *testing.T) {
+		for _, s := range conf.Invalid {
+			_, err := conf.New(s)
+			require.Error(t, err, "New(%q)", s)
+		}
+
+		for _, s := range conf.Valid {
+			_, err := conf.New(s)
+			require.NoError(t, err, "New(%q)", s)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		for _, s := range conf.Valid {
+			v := mustNew(t, conf, s)
+
+			got, err := conf.New(v.String())
+			require.NoError(t, err, "New(%q)", v.String())
+			requireEqual(t, conf, v, got)
+		}
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		t.Run("failure: nil", func(t *testing.T) {
+			var got T
+			require.Error(t, PT(&got).Scan(nil))
+		})
+
+		t.Run("failure: unsupported source type", func(t *testing.T) {
+			var got T
+			require.Error(t, PT(&got).Scan(struct{}{}))
+		})
+
+		t.Run("failure: invalid", func(t *testing.T) {
+			for _, s := range conf.Invalid {
+				var got T
+				require.Error(t, PT(&got).Scan(s), "Scan(%q)", s)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			for _, s := range conf.Valid {
+				v := mustNew(t, conf, s)
+
+				var got1 T
+				require.NoError(t, PT(&got1).Scan(s), "Scan(%q)", s)
+				requireEqual(t, conf, v, got1)
+
+				var got2 T
+				require.NoError(t, PT(&got2).Scan([]byte(s)), "Scan([]byte(%q))", s)
+				requireEqual(t, conf, v, got2)
+			}
+		})
+
+		t.Run("success: no aliasing", func(t *testing.T) {
+			for _, s := range conf.Valid {
+				v := mustNew(t, conf, s)
+
+				b := []byte(s)
+
+				var got T
+				require.NoError(t, PT(&got).Scan(b), "Scan([]byte(%q))", s)
+
+				// drivers may reuse the buffer for the next row
+				for i := range b {
+					b[i] = 0
+				}
+
+				requireEqual(t, conf, v, got)
+			}
+		})
+	})
+
+	t.Run("value and scan", func(t *testing.T) {
+		for _, s := range conf.Valid {
+			v := mustNew(t, conf, s)
+
+			dv, err := v.Value()
+			require.NoError(t, err)
+			require.True(t, driver.IsValue(dv), "Value() returned %T", dv)
+
+			var got T
+			require.NoError(t, PT(&got).Scan(dv))
+			requireEqual(t, conf, v, got)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Run("failure: null", func(t *testing.T) {
+			var got T
+			require.Error(t, PT(&got).UnmarshalJSON([]byte(`null`)))
+		})
+
+		t.Run("failure: invalid", func(t *testing.T) {
+			for _, s := range conf.Invalid {
+				b, err := json.Marshal(s)
+				require.NoError(t, err)
+
+				var got T
+				require.Error(t, PT(&got).UnmarshalJSON(b), "UnmarshalJSON(%s)", b)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			for _, s := range conf.Valid {
+				v := mustNew(t, conf, s)
+
+				b, err := v.MarshalJSON()
+				require.NoError(t, err)
+				require.True(t, json.Valid(b), "MarshalJSON() returned %s", b)
+
+				var got T
+				require.NoError(t, PT(&got).UnmarshalJSON(b))
+				requireEqual(t, conf, v, got)
+			}
+		})
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var zero T
+		if _, ok := any(zero).(encoding.TextMarshaler); !ok {
+			t.Skip("encoding.TextMarshaler is not implemented")
+		}
+		if _, ok := any(&zero).(encoding.TextUnmarshaler); !ok {
+			t.Skip("encoding.TextUnmarshaler is not implemented")
+		}
+
+		for _, s := range conf.Valid {
+			v := mustNew(t, conf, s)
+
+			b, err := any(v).(encoding.TextMarshaler).MarshalText()
+			require.NoError(t, err)
+
+			var got T
+			require.NoError(t, any(&got).(encoding.TextUnmarshaler).UnmarshalText(b))
+			requireEqual(t, conf, v, got)
+		}
+	})
+
+	for _, db := range conf.DBs {
+		t.Run(db.Name, func(t *testing.T) {
+			RunDB[T, PT](t, db, conf)
+		})
+	}
+}
+
+// RunDB round-trips the valid values of conf through a temporary table in db.
+func RunDB[T Value, PT PtrValue[T]](t *testing.T, db DB, conf Config[T]) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	table := fmt.Sprintf("sqlutiltest_%d", time.Now().UnixNano())
+
+	_, err := db.DB.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE %s (id BIGINT NOT NULL PRIMARY KEY, v %s NOT NULL)`, table, db.ColumnType))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		// should not use t.Context()
+		ctx := context.Background()
+
+		_, err := db.DB.ExecContext(ctx, fmt.Sprintf(`DROP TABLE %s`, table))
+		require.NoError(t, err)
+	})
+
+	var (
+		insertQuery = fmt.Sprintf(`INSERT INTO %s (id, v) VALUES (%s, %s)`, table, db.Dialect.Placeholder(1), db.Dialect.Placeholder(2))
+		selectQuery = fmt.Sprintf(`SELECT v FROM %s WHERE id = %s`, table, db.Dialect.Placeholder(1))
+	)
+
+	for i, s := range conf.Valid {
+		v := mustNew(t, conf, s)
+
+		_, err := db.DB.ExecContext(ctx, insertQuery, i, v)
+		require.NoError(t, err, "insert %q", s)
+
+		var got T
+		err = db.DB.QueryRowContext(ctx, selectQuery, i).Scan(PT(&got))
+		require.NoError(t, err, "select %q", s)
+		requireEqual(t, conf, v, got)
+	}
+}
+
+// Fuzz runs a fuzz target that checks New, Scan and UnmarshalJSON agree on arbitrary strings,
+// and that accepted values round-trip through String, Value/Scan and JSON
+// (JSON is skipped if the string form is not valid UTF-8).
+// The valid and invalid strings of conf are added to the seed corpus.
+func Fuzz[T Value, PT PtrValue[T]](f *testing.F, conf Config[T]) {
+	f.Helper()
+
+	for _, s := range conf.Valid {
+		f.Add(s)
+	}
+	for _, s := range conf.Invalid {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := conf.New(s)
+
+		var scanned T
+		scanErr := PT(&scanned).Scan(s)
+
+		if err != nil {
+			require.Error(t, scanErr, "New(%q) failed but Scan succeeded", s)
+
+			return
+		}
+		require.NoError(t, scanErr, "New(%q) succeeded but Scan failed", s)
+		requireEqual(t, conf, v, scanned)
+
+		got, err := conf.New(v.String())
+		require.NoError(t, err, "New(%q)", v.String())
+		requireEqual(t, conf, v, got)
+
+		dv, err := v.Value()
+		require.NoError(t, err)
+
+		var valued T
+		require.NoError(t, PT(&valued).Scan(dv))
+		requireEqual(t, conf, v, valued)
+
+		// JSON strings cannot represent invalid UTF-8
+		if !utf8.ValidString(v.String()) {
+			return
+		}
+
+		b, err := v.MarshalJSON()
+		require.NoError(t, err)
+
+		var unmarshaled T
+		require.NoError(t, PT(&unmarshaled).UnmarshalJSON(b))
+		requireEqual(t, conf, v, unmarshaled)
+	})
+}
+
+func mustNew[T Value](t *testing.T, conf Config[T], s string) T {
+	t.Helper()
+
+	v, err := conf.New(s)
+	require.NoError(t, err, "New(%q)", s)
+
+	return v
+}
+
+func requireEqual[T Value](t *testing.T, conf Config[T], want, got T) {
+	t.Helper()
+
+	if conf.Equal != nil {
+		require.True(t, conf.Equal(want, got), "want %s, got %s", want, got)
+
+		return
+	}
+
+	require.Equal(t, want, got)
+}
@@ -0,0 +1,153 @@
+package sqlutiltest_test
+
+import (
+	"testing"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+	"github.com/m0t0k1ch1-go/sqlutil/v3/sqlutiltest"
+)
+
+type user struct{}
+
+var (
+	httpURLConfig = sqlutiltest.Config[sqlutil.HTTPURL]{
+		New: sqlutil.NewHTTPURLFromString,
+		Valid: []string{
+			"http://m0t0k1ch1.com",
+			"https://m0t0k1ch1.com/task/1?q=1#f",
+		},
+		Invalid: []string{
+			"",
+			"://m0t0k1ch1.com",
+			"http://",
+			"ftp://m0t0k1ch1.com",
+		},
+		// url.URL keeps the raw fragment, which is not preserved by String
+		Equal: func(a, b sqlutil.HTTPURL) bool {
+			return a.String() == b.String()
+		},
+	}
+	emailConfig = sqlutiltest.Config[sqlutil.Email]{
+		New: func(s string) (sqlutil.Email, error) {
+			return sqlutil.NewEmail(s)
+		},
+		Valid: []string{
+			"m0t0k1ch1@example.com",
+			"M0t0k1ch1@EXAMPLE.com",
+		},
+		Invalid: []string{
+			"",
+			"m0t0k1ch1",
+			"m0t0k1ch1 <m0t0k1ch1@example.com>",
+		},
+	}
+	ipAddrConfig = sqlutiltest.Config[sqlutil.IPAddr]{
+		New: sqlutil.NewIPAddrFromString,
+		Valid: []string{
+			"192.0.2.1",
+			"2001:db8::1",
+		},
+		Invalid: []string{
+			"",
+			"192.0.2",
+			"192.0.2.0/24",
+		},
+	}
+	ipPrefixConfig = sqlutiltest.Config[sqlutil.IPPrefix]{
+		New: sqlutil.NewIPPrefixFromString,
+		Valid: []string{
+			"192.0.2.0/24",
+			"2001:db8::/32",
+		},
+		Invalid: []string{
+			"",
+			"192.0.2.1/24",
+		},
+	}
+	utcTimeConfig = sqlutiltest.Config[sqlutil.UTCTime]{
+		New: sqlutil.NewUTCTimeFromString,
+		Valid: []string{
+			"2025-01-02T03:04:05Z",
+			"2025-01-02 12:04:05.123456+09",
+		},
+		Invalid: []string{
+			"",
+			"2025-01-02",
+		},
+	}
+	dateConfig = sqlutiltest.Config[sqlutil.Date]{
+		New: sqlutil.NewDateFromString,
+		Valid: []string{
+			"2025-01-02",
+		},
+		Invalid: []string{
+			"",
+			"0000-00-00",
+		},
+	}
+	unixTimeConfig = sqlutiltest.Config[sqlutil.UnixTime]{
+		New: sqlutil.NewUnixTimeFromString,
+		Valid: []string{
+			"0",
+			"1735787045",
+		},
+		Invalid: []string{
+			"",
+			"1.5",
+		},
+	}
+	idConfig = sqlutiltest.Config[sqlutil.ID[user]]{
+		New: sqlutil.NewIDFromString[user],
+		Valid: []string{
+			"1",
+			"9007199254740993",
+		},
+		Invalid: []string{
+			"",
+			"a",
+		},
+	}
+)
+
+func TestRun(t *testing.T) {
+	t.Run("HTTPURL", func(t *testing.T) {
+		sqlutiltest.Run(t, httpURLConfig)
+	})
+	t.Run("Email", func(t *testing.T) {
+		sqlutiltest.Run(t, emailConfig)
+	})
+	t.Run("IPAddr", func(t *testing.T) {
+		sqlutiltest.Run(t, ipAddrConfig)
+	})
+	t.Run("IPPrefix", func(t *testing.T) {
+		sqlutiltest.Run(t, ipPrefixConfig)
+	})
+	t.Run("UTCTime", func(t *testing.T) {
+		sqlutiltest.Run(t, utcTimeConfig)
+	})
+	t.Run("Date", func(t *testing.T) {
+		sqlutiltest.Run(t, dateConfig)
+	})
+	t.Run("UnixTime", func(t *testing.T) {
+		sqlutiltest.Run(t, unixTimeConfig)
+	})
+	t.Run("ID", func(t *testing.T) {
+		sqlutiltest.Run(t, idConfig)
+	})
+}
+
+func FuzzHTTPURL(f *testing.F) {
+	sqlutiltest.Fuzz(f, httpURLConfig)
+}
+
+func FuzzEmail(f *testing.F) {
+	sqlutiltest.Fuzz(f, emailConfig)
+}
+
+func FuzzIPAddr(f *testing.F) {
+	sqlutiltest.Fuzz(f, ipAddrConfig)
+}
+
+func FuzzIPPrefix(f *testing.F) {
+	sqlutiltest.Fuzz(f, ipPrefixConfig)
+}
@@ -4,3 +4,17 @@ CREATE TABLE task (
   url TEXT NOT NULL,
   is_completed BOOLEAN NOT NULL DEFAULT false
 );
+
+CREATE TABLE versioned_task (
+  id BIGINT NOT NULL PRIMARY KEY,
+  title VARCHAR(255) NOT NULL,
+  version BIGINT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMP(6) NOT NULL
+);
+
+CREATE TABLE auto_task (
+  id SERIAL PRIMARY KEY,
+  title VARCHAR(255) NOT NULL,
+  url TEXT NOT NULL,
+  is_completed BOOLEAN NOT NULL DEFAULT false
+);
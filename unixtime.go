@@ -0,0 +1,155 @@
+package sqlutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// UnixTime represents a time.Time stored as seconds since the Unix epoch (BIGINT).
+type UnixTime struct {
+	t time.Time
+}
+
+// NewUnixTime returns a new UnixTime truncated to seconds.
+func NewUnixTime(t time.Time) UnixTime {
+	return NewUnixTimeFromInt64(t.Unix())
+}
+
+// NewUnixTimeFromInt64 returns a new UnixTime from seconds since the Unix epoch.
+func NewUnixTimeFromInt64(sec int64) UnixTime {
+	return UnixTime{
+		t: time.Unix(sec, 0).UTC(),
+	}
+}
+
+// NewUnixTimeFromString returns a new UnixTime from a decimal string of seconds since the Unix epoch.
+func NewUnixTimeFromString(s string) (UnixTime, error) {
+	var ut UnixTime
+	if err := ut.setString(s); err != nil {
+		return UnixTime{}, err
+	}
+
+	return ut, nil
+}
+
+// MustNewUnixTimeFromString panics if the input is invalid.
+func MustNewUnixTimeFromString(s string) UnixTime {
+	ut, err := NewUnixTimeFromString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return ut
+}
+
+func (ut *UnixTime) setString(s string) error {
+	if len(s) == 0 {
+		return errors.New("invalid unix time string: empty")
+	}
+
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid unix time string: %w", err)
+	}
+
+	*ut = NewUnixTimeFromInt64(sec)
+
+	return nil
+}
+
+// Time returns the underlying time.Time in UTC.
+func (ut UnixTime) Time() time.Time {
+	return ut.t
+}
+
+// Unix returns the value as seconds since the Unix epoch.
+func (ut UnixTime) Unix() int64 {
+	return ut.t.Unix()
+}
+
+// String implements fmt.Stringer.
+// It returns the value as a decimal string of seconds since the Unix epoch.
+func (ut UnixTime) String() string {
+	return strconv.FormatInt(ut.Unix(), 10)
+}
+
+// Value implements driver.Valuer.
+// It returns the value as an int64.
+func (ut UnixTime) Value() (driver.Value, error) {
+	return ut.Unix(), nil
+}
+
+// Scan implements sql.Scanner.
+// It accepts an int64, string or []byte.
+func (ut *UnixTime) Scan(src any) error {
+	if src == nil {
+		return errors.New("invalid source: nil")
+	}
+
+	var s string
+	{
+		switch v := src.(type) {
+		case int64:
+			*ut = NewUnixTimeFromInt64(v)
+
+			return nil
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return fmt.Errorf("unsupported source type: %T", src)
+		}
+	}
+
+	if err := ut.setString(s); err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the value as a decimal string of seconds since the Unix epoch.
+func (ut UnixTime) MarshalText() ([]byte, error) {
+	return []byte(ut.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (ut *UnixTime) UnmarshalText(b []byte) error {
+	if err := ut.setString(string(b)); err != nil {
+		return fmt.Errorf("invalid text: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns the value as a JSON number.
+func (ut UnixTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ut.Unix())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a JSON number.
+func (ut *UnixTime) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 {
+		return errors.New("invalid json value: empty")
+	}
+	if string(b) == "null" {
+		return errors.New("invalid json value: null")
+	}
+
+	var sec int64
+	if err := json.Unmarshal(b, &sec); err != nil {
+		return fmt.Errorf("invalid json number: %w", err)
+	}
+
+	*ut = NewUnixTimeFromInt64(sec)
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestUnixTime(t *testing.T) {
+	var ut sqlutil.UnixTime
+	require.Implements(t, (*fmt.Stringer)(nil), &ut)
+	require.Implements(t, (*driver.Valuer)(nil), &ut)
+	require.Implements(t, (*sql.Scanner)(nil), &ut)
+	require.Implements(t, (*encoding.TextMarshaler)(nil), &ut)
+	require.Implements(t, (*encoding.TextUnmarshaler)(nil), &ut)
+	require.Implements(t, (*json.Marshaler)(nil), &ut)
+	require.Implements(t, (*json.Unmarshaler)(nil), &ut)
+}
+
+func TestNewUnixTime(t *testing.T) {
+	ut := sqlutil.NewUnixTime(time.Date(2025, 1, 2, 12, 4, 5, 999999999, time.FixedZone("JST", 9*60*60)))
+	require.Equal(t, int64(1735787045), ut.Unix())
+	require.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), ut.Time())
+}
+
+func TestNewUnixTimeFromString(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"empty",
+				"",
+				"invalid unix time string: empty",
+			},
+			{
+				"not a number",
+				"2025-01-02",
+				"invalid unix time string",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.NewUnixTimeFromString(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ut, err := sqlutil.NewUnixTimeFromString("1735787045")
+		require.NoError(t, err)
+		require.Equal(t, int64(1735787045), ut.Unix())
+	})
+}
+
+func TestUnixTime_Value(t *testing.T) {
+	v, err := sqlutil.NewUnixTimeFromInt64(1735787045).Value()
+	require.NoError(t, err)
+	require.Equal(t, int64(1735787045), v)
+}
+
+func TestUnixTime_Scan(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"nil",
+				nil,
+				"invalid source: nil",
+			},
+			{
+				"time.Time",
+				time.Now(),
+				"unsupported source type: time.Time",
+			},
+			{
+				"string: empty",
+				"",
+				"invalid source: invalid unix time string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var ut sqlutil.UnixTime
+				err := ut.Scan(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+		}{
+			{
+				"int64",
+				int64(1735787045),
+			},
+			{
+				"string",
+				"1735787045",
+			},
+			{
+				"[]byte",
+				[]byte("1735787045"),
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var ut sqlutil.UnixTime
+				err := ut.Scan(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, int64(1735787045), ut.Unix())
+			})
+		}
+	})
+}
+
+func TestUnixTime_MarshalText(t *testing.T) {
+	b, err := sqlutil.NewUnixTimeFromInt64(1735787045).MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, []byte("1735787045"), b)
+}
+
+func TestUnixTime_UnmarshalText(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		var ut sqlutil.UnixTime
+		err := ut.UnmarshalText([]byte("x"))
+		require.ErrorContains(t, err, "invalid text: invalid unix time string")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var ut sqlutil.UnixTime
+		err := ut.UnmarshalText([]byte("1735787045"))
+		require.NoError(t, err)
+		require.Equal(t, int64(1735787045), ut.Unix())
+	})
+}
+
+func TestUnixTime_MarshalJSON(t *testing.T) {
+	b, err := sqlutil.NewUnixTimeFromInt64(1735787045).MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`1735787045`), b)
+}
+
+func TestUnixTime_UnmarshalJSON(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   []byte
+			want string
+		}{
+			{
+				"empty",
+				[]byte{},
+				"invalid json value: empty",
+			},
+			{
+				"null",
+				[]byte(`null`),
+				"invalid json value: null",
+			},
+			{
+				"string",
+				[]byte(`"1735787045"`),
+				"invalid json number",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var ut sqlutil.UnixTime
+				err := ut.UnmarshalJSON(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var ut sqlutil.UnixTime
+		err := ut.UnmarshalJSON([]byte(`1735787045`))
+		require.NoError(t, err)
+		require.Equal(t, int64(1735787045), ut.Unix())
+	})
+}
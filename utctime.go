@@ -0,0 +1,172 @@
+package sqlutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultUTCTimePrecision is the precision applied by NewUTCTime and UTCTime.Scan.
+// Both MySQL DATETIME(6) and PostgreSQL timestamp store microseconds.
+const DefaultUTCTimePrecision = time.Microsecond
+
+var utcTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// UTCTime represents a time.Time normalized to UTC.
+type UTCTime struct {
+	t time.Time
+}
+
+// NewUTCTime returns a new UTCTime truncated to DefaultUTCTimePrecision.
+func NewUTCTime(t time.Time) UTCTime {
+	return NewUTCTimeWithPrecision(t, DefaultUTCTimePrecision)
+}
+
+// NewUTCTimeWithPrecision returns a new UTCTime truncated to the given precision.
+// If d <= 0, the value is not truncated.
+func NewUTCTimeWithPrecision(t time.Time, d time.Duration) UTCTime {
+	return UTCTime{
+		t: t.UTC().Truncate(d),
+	}
+}
+
+// NewUTCTimeFromString returns a new UTCTime from a string.
+// It accepts RFC 3339 and the text formats of MySQL and PostgreSQL.
+// A string without a time zone offset is interpreted as UTC.
+func NewUTCTimeFromString(s string) (UTCTime, error) {
+	var ut UTCTime
+	if err := ut.setString(s); err != nil {
+		return UTCTime{}, err
+	}
+
+	return ut, nil
+}
+
+// MustNewUTCTimeFromString panics if the input is invalid.
+func MustNewUTCTimeFromString(s string) UTCTime {
+	ut, err := NewUTCTimeFromString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return ut
+}
+
+func (ut *UTCTime) setString(s string) error {
+	if len(s) == 0 {
+		return errors.New("invalid time string: empty")
+	}
+
+	for _, layout := range utcTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			*ut = NewUTCTime(t)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid time string: unsupported format: %q", s)
+}
+
+// Time returns the underlying time.Time.
+func (ut UTCTime) Time() time.Time {
+	return ut.t
+}
+
+// IsZero reports whether the value is the zero time.
+func (ut UTCTime) IsZero() bool {
+	return ut.t.IsZero()
+}
+
+// String implements fmt.Stringer.
+// It returns the value in RFC 3339 format.
+func (ut UTCTime) String() string {
+	return ut.t.Format(time.RFC3339Nano)
+}
+
+// Value implements driver.Valuer.
+// It returns the value as a time.Time in UTC.
+// When using github.com/go-sql-driver/mysql, ensure `loc=UTC` (the default).
+func (ut UTCTime) Value() (driver.Value, error) {
+	return ut.t, nil
+}
+
+// Scan implements sql.Scanner.
+// It accepts a time.Time, string or []byte.
+func (ut *UTCTime) Scan(src any) error {
+	if src == nil {
+		return errors.New("invalid source: nil")
+	}
+
+	var s string
+	{
+		switch v := src.(type) {
+		case time.Time:
+			*ut = NewUTCTime(v)
+
+			return nil
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return fmt.Errorf("unsupported source type: %T", src)
+		}
+	}
+
+	if err := ut.setString(s); err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the value in RFC 3339 format.
+func (ut UTCTime) MarshalText() ([]byte, error) {
+	return []byte(ut.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (ut *UTCTime) UnmarshalText(b []byte) error {
+	if err := ut.setString(string(b)); err != nil {
+		return fmt.Errorf("invalid text: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns the value as a JSON string in RFC 3339 format.
+func (ut UTCTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ut.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a JSON string.
+func (ut *UTCTime) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 {
+		return errors.New("invalid json value: empty")
+	}
+	if string(b) == "null" {
+		return errors.New("invalid json value: null")
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	if err := ut.setString(s); err != nil {
+		return fmt.Errorf("invalid json string: %w", err)
+	}
+
+	return nil
+}
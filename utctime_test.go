@@ -0,0 +1,312 @@
+package sqlutil_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+func TestUTCTime(t *testing.T) {
+	var ut sqlutil.UTCTime
+	require.Implements(t, (*fmt.Stringer)(nil), &ut)
+	require.Implements(t, (*driver.Valuer)(nil), &ut)
+	require.Implements(t, (*sql.Scanner)(nil), &ut)
+	require.Implements(t, (*encoding.TextMarshaler)(nil), &ut)
+	require.Implements(t, (*encoding.TextUnmarshaler)(nil), &ut)
+	require.Implements(t, (*json.Marshaler)(nil), &ut)
+	require.Implements(t, (*json.Unmarshaler)(nil), &ut)
+}
+
+func TestNewUTCTime(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   time.Time
+			want string
+		}{
+			{
+				"utc",
+				time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+				"2025-01-02T03:04:05Z",
+			},
+			{
+				"jst",
+				time.Date(2025, 1, 2, 3, 4, 5, 0, jst),
+				"2025-01-01T18:04:05Z",
+			},
+			{
+				"nanoseconds",
+				time.Date(2025, 1, 2, 3, 4, 5, 123456789, time.UTC),
+				"2025-01-02T03:04:05.123456Z",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				ut := sqlutil.NewUTCTime(tc.in)
+				require.Equal(t, tc.want, ut.String())
+				require.Equal(t, time.UTC, ut.Time().Location())
+			})
+		}
+	})
+}
+
+func TestNewUTCTimeWithPrecision(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   time.Duration
+			want string
+		}{
+			{
+				"no truncation",
+				0,
+				"2025-01-02T03:04:05.123456789Z",
+			},
+			{
+				"millisecond",
+				time.Millisecond,
+				"2025-01-02T03:04:05.123Z",
+			},
+			{
+				"second",
+				time.Second,
+				"2025-01-02T03:04:05Z",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				ut := sqlutil.NewUTCTimeWithPrecision(time.Date(2025, 1, 2, 3, 4, 5, 123456789, time.UTC), tc.in)
+				require.Equal(t, tc.want, ut.String())
+			})
+		}
+	})
+}
+
+func TestNewUTCTimeFromString(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"empty",
+				"",
+				"invalid time string: empty",
+			},
+			{
+				"date only",
+				"2025-01-02",
+				"invalid time string: unsupported format",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.NewUTCTimeFromString(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				"rfc3339",
+				"2025-01-02T12:04:05+09:00",
+				"2025-01-02T03:04:05Z",
+			},
+			{
+				"mysql",
+				"2025-01-02 03:04:05.123456",
+				"2025-01-02T03:04:05.123456Z",
+			},
+			{
+				"postgresql: timestamp",
+				"2025-01-02 03:04:05",
+				"2025-01-02T03:04:05Z",
+			},
+			{
+				"postgresql: timestamptz",
+				"2025-01-02 12:04:05.5+09",
+				"2025-01-02T03:04:05.5Z",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				ut, err := sqlutil.NewUTCTimeFromString(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, ut.String())
+			})
+		}
+	})
+}
+
+func TestUTCTime_Value(t *testing.T) {
+	ut := sqlutil.MustNewUTCTimeFromString("2025-01-02T12:04:05+09:00")
+
+	v, err := ut.Value()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), v)
+}
+
+func TestUTCTime_Scan(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"nil",
+				nil,
+				"invalid source: nil",
+			},
+			{
+				"int64",
+				int64(1),
+				"unsupported source type: int64",
+			},
+			{
+				"string: empty",
+				"",
+				"invalid source: invalid time string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var ut sqlutil.UTCTime
+				err := ut.Scan(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   any
+			want string
+		}{
+			{
+				"time.Time",
+				time.Date(2025, 1, 2, 12, 4, 5, 123456789, time.FixedZone("JST", 9*60*60)),
+				"2025-01-02T03:04:05.123456Z",
+			},
+			{
+				"string",
+				"2025-01-02 03:04:05",
+				"2025-01-02T03:04:05Z",
+			},
+			{
+				"[]byte",
+				[]byte("2025-01-02 03:04:05.123456"),
+				"2025-01-02T03:04:05.123456Z",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var ut sqlutil.UTCTime
+				err := ut.Scan(tc.in)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, ut.String())
+			})
+		}
+	})
+}
+
+func TestUTCTime_MarshalText(t *testing.T) {
+	ut := sqlutil.MustNewUTCTimeFromString("2025-01-02T03:04:05Z")
+
+	b, err := ut.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, []byte("2025-01-02T03:04:05Z"), b)
+}
+
+func TestUTCTime_UnmarshalText(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		var ut sqlutil.UTCTime
+		err := ut.UnmarshalText([]byte{})
+		require.ErrorContains(t, err, "invalid text: invalid time string: empty")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var ut sqlutil.UTCTime
+		err := ut.UnmarshalText([]byte("2025-01-02T03:04:05Z"))
+		require.NoError(t, err)
+		require.Equal(t, "2025-01-02T03:04:05Z", ut.String())
+	})
+}
+
+func TestUTCTime_MarshalJSON(t *testing.T) {
+	ut := sqlutil.MustNewUTCTimeFromString("2025-01-02T03:04:05.123456Z")
+
+	b, err := ut.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`"2025-01-02T03:04:05.123456Z"`), b)
+}
+
+func TestUTCTime_UnmarshalJSON(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			in   []byte
+			want string
+		}{
+			{
+				"empty",
+				[]byte{},
+				"invalid json value: empty",
+			},
+			{
+				"null",
+				[]byte(`null`),
+				"invalid json value: null",
+			},
+			{
+				"number",
+				[]byte(`1`),
+				"invalid json string",
+			},
+			{
+				"string: empty",
+				[]byte(`""`),
+				"invalid json string: invalid time string: empty",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				var ut sqlutil.UTCTime
+				err := ut.UnmarshalJSON(tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var ut sqlutil.UTCTime
+		err := ut.UnmarshalJSON([]byte(`"2025-01-02T12:04:05+09:00"`))
+		require.NoError(t, err)
+		require.Equal(t, "2025-01-02T03:04:05Z", ut.String())
+	})
+}
@@ -0,0 +1,180 @@
+package sqlutil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrStaleVersion is returned when a row to be updated no longer has the expected version,
+// i.e. it has been updated or deleted concurrently since it was read.
+var ErrStaleVersion = errors.New("stale version")
+
+// Version is the version of a row for optimistic concurrency control,
+// held in a column that is either an integer counter or a timestamp.
+type Version struct {
+	column string
+	n      int64
+	t      time.Time
+	isTime bool
+}
+
+// IntVersion returns a Version held in an integer column incremented on each update.
+func IntVersion(column string, current int64) Version {
+	return Version{
+		column: column,
+		n:      current,
+	}
+}
+
+// TimeVersion returns a Version held in a timestamp column (e.g. updated_at) set to the current time on each update.
+// The column must store microseconds (e.g. DATETIME(6) on MySQL),
+// and current must be the value read from it.
+func TimeVersion(column string, current time.Time) Version {
+	return Version{
+		column: column,
+		t:      current,
+		isTime: true,
+	}
+}
+
+// Column returns the name of the version column.
+func (v Version) Column() string {
+	return v.column
+}
+
+// Int returns the value of an IntVersion.
+func (v Version) Int() int64 {
+	return v.n
+}
+
+// Time returns the value of a TimeVersion.
+func (v Version) Time() time.Time {
+	return v.t
+}
+
+// next returns the version to be set by an update.
+func (v Version) next() Version {
+	if !v.isTime {
+		v.n++
+
+		return v
+	}
+
+	t := time.Now().UTC().Truncate(time.Microsecond)
+	if !t.After(v.t) {
+		t = v.t.Add(time.Microsecond)
+	}
+	v.t = t
+
+	return v
+}
+
+// VersionedUpdate describes an UPDATE of a row guarded by its version.
+type VersionedUpdate struct {
+	Table Fragment
+	// Columns and Values are the columns to set and their values.
+	Columns []string
+	Values  []any
+	// KeyColumns and KeyValues identify the row to update.
+	KeyColumns []string
+	KeyValues  []any
+	Version    Version
+}
+
+// UpdateVersioned updates the row only if it still has the expected version, advancing the version,
+// and returns the new version.
+// It returns ErrStaleVersion if no row matches, i.e. the row has been updated or deleted concurrently.
+//
+// The statement is like `UPDATE task SET title = ?, version = version + 1 WHERE id = ? AND version = ?`.
+// Since the version always changes, rows affected is reliable even on MySQL.
+func UpdateVersioned(ctx context.Context, queryExecutor QueryExecutor, dialect Dialect, u VersionedUpdate) (Version, error) {
+	if err := dialect.validate(); err != nil {
+		return Version{}, err
+	}
+	if u.Table.IsZero() {
+		return Version{}, errors.New("invalid update: empty table")
+	}
+	if len(u.Columns) != len(u.Values) {
+		return Version{}, fmt.Errorf("invalid update: %d values for %d columns", len(u.Values), len(u.Columns))
+	}
+	if len(u.KeyColumns) == 0 {
+		return Version{}, errors.New("invalid update: no key columns")
+	}
+	if len(u.KeyColumns) != len(u.KeyValues) {
+		return Version{}, fmt.Errorf("invalid update: %d values for %d key columns", len(u.KeyValues), len(u.KeyColumns))
+	}
+	if u.Version.column == "" {
+		return Version{}, errors.New("invalid update: empty version column")
+	}
+
+	next := u.Version.next()
+
+	var (
+		sb   strings.Builder
+		args []any
+	)
+	placeholder := func(v any) string {
+		args = append(args, v)
+
+		return dialect.Placeholder(len(args))
+	}
+
+	versionCol := dialect.QuoteIdentifier(u.Version.column)
+
+	sb.WriteString("UPDATE ")
+	sb.WriteString(u.Table.String())
+	sb.WriteString(" SET ")
+	for i, col := range u.Columns {
+		sb.WriteString(dialect.QuoteIdentifier(col) + " = " + placeholder(u.Values[i]) + ", ")
+	}
+	if next.isTime {
+		sb.WriteString(versionCol + " = " + placeholder(next.t))
+	} else {
+		sb.WriteString(versionCol + " = " + versionCol + " + 1")
+	}
+	sb.WriteString(" WHERE ")
+	for i, col := range u.KeyColumns {
+		sb.WriteString(dialect.QuoteIdentifier(col) + " = " + placeholder(u.KeyValues[i]) + " AND ")
+	}
+	if u.Version.isTime {
+		sb.WriteString(versionCol + " = " + placeholder(u.Version.t))
+	} else {
+		sb.WriteString(versionCol + " = " + placeholder(u.Version.n))
+	}
+
+	if _, err := ExecExpect(ctx, queryExecutor, ExactRows(1), sb.String(), args...); err != nil {
+		if errors.Is(err, ErrNoRowsAffected) {
+			return Version{}, fmt.Errorf("%w: %s", ErrStaleVersion, u.Table)
+		}
+
+		return Version{}, err
+	}
+
+	return next, nil
+}
+
+// TransactRetryStale runs the given function within a transaction by Transact,
+// retrying it with a new transaction up to maxAttempts times in total while it returns ErrStaleVersion.
+// The function should read the row, including its version, within the transaction
+// so that each attempt works on the latest row.
+func TransactRetryStale(ctx context.Context, txStarter TxStarter, maxAttempts int, f func(context.Context, *sql.Tx) error) error {
+	if maxAttempts < 1 {
+		return fmt.Errorf("invalid max attempts: %d", maxAttempts)
+	}
+
+	var err error
+	for range maxAttempts {
+		if err = Transact(ctx, txStarter, f); !errors.Is(err, ErrStaleVersion) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
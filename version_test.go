@@ -0,0 +1,253 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type versionTestTask struct {
+	ID        int64     `db:"id"`
+	Title     string    `db:"title"`
+	Version   int64     `db:"version"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func TestUpdateVersioned(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		table := sqlutil.DialectMySQL.Identifier("versioned_task")
+
+		tcs := []struct {
+			name         string
+			in           sqlutil.VersionedUpdate
+			rowsAffected int64
+			wantErr      error
+			want         string
+		}{
+			{
+				"empty table",
+				sqlutil.VersionedUpdate{
+					KeyColumns: []string{"id"},
+					KeyValues:  []any{1},
+					Version:    sqlutil.IntVersion("version", 0),
+				},
+				1,
+				nil,
+				"invalid update: empty table",
+			},
+			{
+				"values mismatch",
+				sqlutil.VersionedUpdate{
+					Table:      table,
+					Columns:    []string{"title"},
+					KeyColumns: []string{"id"},
+					KeyValues:  []any{1},
+					Version:    sqlutil.IntVersion("version", 0),
+				},
+				1,
+				nil,
+				"invalid update: 0 values for 1 columns",
+			},
+			{
+				"no key columns",
+				sqlutil.VersionedUpdate{
+					Table:   table,
+					Version: sqlutil.IntVersion("version", 0),
+				},
+				1,
+				nil,
+				"invalid update: no key columns",
+			},
+			{
+				"empty version column",
+				sqlutil.VersionedUpdate{
+					Table:      table,
+					KeyColumns: []string{"id"},
+					KeyValues:  []any{1},
+				},
+				1,
+				nil,
+				"invalid update: empty version column",
+			},
+			{
+				"stale version",
+				sqlutil.VersionedUpdate{
+					Table:      table,
+					KeyColumns: []string{"id"},
+					KeyValues:  []any{1},
+					Version:    sqlutil.IntVersion("version", 0),
+				},
+				0,
+				sqlutil.ErrStaleVersion,
+				"stale version: `versioned_task`",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := sqlutil.UpdateVersioned(t.Context(), execTestQueryExecutor{tc.rowsAffected}, sqlutil.DialectMySQL, tc.in)
+				if tc.wantErr != nil {
+					require.ErrorIs(t, err, tc.wantErr)
+				}
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	tcs := []struct {
+		name    string
+		db      *sql.DB
+		dialect sqlutil.Dialect
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			sqlutil.DialectMySQL,
+		},
+		{
+			"postgresql",
+			psqlDB,
+			sqlutil.DialectPostgreSQL,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			updatedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			setupVersionedTasks(t, tc.db, tc.dialect, updatedAt)
+
+			table := tc.dialect.Identifier("versioned_task")
+
+			getTask := func(t *testing.T, ctx context.Context, querier sqlutil.Querier) versionTestTask {
+				t.Helper()
+
+				task, err := sqlutil.QueryOne[versionTestTask](ctx, querier, `SELECT id, title, version, updated_at FROM versioned_task WHERE id = 1`)
+				require.NoError(t, err)
+
+				return task
+			}
+
+			t.Run("int version", func(t *testing.T) {
+				ctx := t.Context()
+
+				update := sqlutil.VersionedUpdate{
+					Table:      table,
+					Columns:    []string{"title"},
+					Values:     []any{"task1 updated"},
+					KeyColumns: []string{"id"},
+					KeyValues:  []any{1},
+					Version:    sqlutil.IntVersion("version", 0),
+				}
+
+				v, err := sqlutil.UpdateVersioned(ctx, tc.db, tc.dialect, update)
+				require.NoError(t, err)
+				require.Equal(t, int64(1), v.Int())
+
+				// the same values, but with the stale version
+				_, err = sqlutil.UpdateVersioned(ctx, tc.db, tc.dialect, update)
+				require.ErrorIs(t, err, sqlutil.ErrStaleVersion)
+
+				task := getTask(t, ctx, tc.db)
+				require.Equal(t, "task1 updated", task.Title)
+				require.Equal(t, int64(1), task.Version)
+			})
+
+			t.Run("time version", func(t *testing.T) {
+				ctx := t.Context()
+
+				update := sqlutil.VersionedUpdate{
+					Table:      table,
+					Columns:    []string{"title"},
+					Values:     []any{"task1 updated again"},
+					KeyColumns: []string{"id"},
+					KeyValues:  []any{1},
+					Version:    sqlutil.TimeVersion("updated_at", updatedAt),
+				}
+
+				v, err := sqlutil.UpdateVersioned(ctx, tc.db, tc.dialect, update)
+				require.NoError(t, err)
+				require.True(t, v.Time().After(updatedAt))
+
+				_, err = sqlutil.UpdateVersioned(ctx, tc.db, tc.dialect, update)
+				require.ErrorIs(t, err, sqlutil.ErrStaleVersion)
+
+				task := getTask(t, ctx, tc.db)
+				require.Equal(t, "task1 updated again", task.Title)
+				require.True(t, v.Time().Equal(task.UpdatedAt))
+			})
+
+			t.Run("retry", func(t *testing.T) {
+				ctx := t.Context()
+
+				var attempts int
+				err := sqlutil.TransactRetryStale(ctx, tc.db, 3, func(ctx context.Context, tx *sql.Tx) error {
+					attempts++
+
+					task := getTask(t, ctx, tx)
+
+					version := task.Version
+					if attempts == 1 {
+						// simulate a concurrent update
+						version--
+					}
+
+					_, err := sqlutil.UpdateVersioned(ctx, tx, tc.dialect, sqlutil.VersionedUpdate{
+						Table:      table,
+						Columns:    []string{"title"},
+						Values:     []any{"task1 retried"},
+						KeyColumns: []string{"id"},
+						KeyValues:  []any{1},
+						Version:    sqlutil.IntVersion("version", version),
+					})
+
+					return err
+				})
+				require.NoError(t, err)
+				require.Equal(t, 2, attempts)
+
+				require.Equal(t, "task1 retried", getTask(t, ctx, tc.db).Title)
+			})
+
+			t.Run("retry: exhausted", func(t *testing.T) {
+				ctx := t.Context()
+
+				errSomethingWentWrong := errors.New("something went wrong")
+
+				var attempts int
+				err := sqlutil.TransactRetryStale(ctx, tc.db, 3, func(ctx context.Context, tx *sql.Tx) error {
+					attempts++
+
+					return errors.Join(errSomethingWentWrong, sqlutil.ErrStaleVersion)
+				})
+				require.ErrorIs(t, err, sqlutil.ErrStaleVersion)
+				require.Equal(t, 3, attempts)
+			})
+		})
+	}
+}
+
+func setupVersionedTasks(t *testing.T, db *sql.DB, dialect sqlutil.Dialect, updatedAt time.Time) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	_, err := sqlutil.BulkInsert(ctx, db, dialect, dialect.Identifier("versioned_task"), []string{"id", "title", "updated_at"}, [][]any{
+		{1, "task1", updatedAt},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		// should not use t.Context()
+		ctx := context.Background()
+
+		_, err := db.ExecContext(ctx, `TRUNCATE versioned_task`)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,299 @@
+package sqlutil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Insert inserts the struct pointed to by v into the table.
+// The columns are derived from the `db` tags as in ScanOne:
+// fields with the omitempty option are omitted if they have the zero value,
+// and fields with the auto option are always omitted so that the database generates them.
+//
+// The generated values are written back to v:
+// on MySQL, LastInsertId is set to the field tagged with both pk and auto (e.g. `db:"id,pk,auto"`),
+// which must be an integer or implement sql.Scanner (e.g. ID),
+// and on the other dialects, all the auto fields are set by `RETURNING`,
+// in which case queryExecutor must also implement Querier.
+// The query runs with a context marked by WithPrimary, so that a Router sends it to the primary.
+func Insert(ctx context.Context, queryExecutor QueryExecutor, dialect Dialect, table Fragment, v any) error {
+	if err := dialect.validate(); err != nil {
+		return err
+	}
+	if table.IsZero() {
+		return errors.New("invalid table: empty")
+	}
+
+	rv, meta, err := structPointerOf(v)
+	if err != nil {
+		return err
+	}
+
+	var (
+		columns []string
+		args    []any
+		autos   []fieldMeta
+	)
+	for _, fm := range meta.fields {
+		if fm.auto {
+			autos = append(autos, fm)
+
+			continue
+		}
+
+		fv, err := rv.FieldByIndexErr(fm.index)
+		if err != nil {
+			// through a nil embedded pointer
+			if !fm.omitEmpty {
+				columns = append(columns, fm.name)
+				args = append(args, nil)
+			}
+
+			continue
+		}
+		if fm.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		columns = append(columns, fm.name)
+		args = append(args, fv.Interface())
+	}
+
+	var query string
+	switch {
+	case len(columns) > 0:
+		query, _ = buildInsertSQL(dialect, table, columns, [][]any{args})
+	case dialect == DialectMySQL:
+		query = "INSERT INTO " + table.String() + " () VALUES ()"
+	default:
+		query = "INSERT INTO " + table.String() + " DEFAULT VALUES"
+	}
+
+	// on MySQL, the type of the field for LastInsertId is checked before the INSERT runs
+	var lastInsertID *fieldMeta
+	if dialect == DialectMySQL {
+		for _, fm := range autos {
+			if !fm.pk {
+				continue
+			}
+
+			if typ := rv.Type().FieldByIndex(fm.index).Type; !canSetLastInsertID(typ) {
+				return fmt.Errorf("unsupported type: %s: last insert id requires an integer or a sql.Scanner", typ)
+			}
+
+			lastInsertID = &fm
+
+			break
+		}
+	}
+
+	if dialect == DialectMySQL || len(autos) == 0 {
+		res, err := execContext(ctx, queryExecutor, query, args...)
+		if err != nil {
+			return err
+		}
+
+		if lastInsertID == nil {
+			return nil
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		return setLastInsertID(fieldByIndexAlloc(rv, lastInsertID.index), id)
+	}
+
+	querier, ok := queryExecutor.(Querier)
+	if !ok {
+		return fmt.Errorf("invalid query executor: %T does not implement Querier", queryExecutor)
+	}
+
+	return insertReturning(ctx, querier, dialect, rv, autos, query, args)
+}
+
+// canSetLastInsertID reports whether setLastInsertID accepts a field of type typ.
+func canSetLastInsertID(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return reflect.PointerTo(typ).Implements(reflect.TypeFor[sql.Scanner]())
+	}
+}
+
+// setLastInsertID sets id to fv, through sql.Scanner if fv is not an integer (e.g. ID).
+func setLastInsertID(fv reflect.Value, id int64) error {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(id)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv.SetUint(uint64(id))
+	default:
+		if err := fv.Addr().Interface().(sql.Scanner).Scan(id); err != nil {
+			return fmt.Errorf("failed to set last insert id: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// insertReturning runs the INSERT with `RETURNING` the auto fields and scans them into rv.
+func insertReturning(ctx context.Context, querier Querier, dialect Dialect, rv reflect.Value, autos []fieldMeta, query string, args []any) (err error) {
+	returning := make([]string, len(autos))
+	for i, fm := range autos {
+		returning[i] = dialect.QuoteIdentifier(fm.name)
+	}
+	query += " RETURNING " + strings.Join(returning, ", ")
+
+	// it writes, even though it returns rows
+	rows, err := queryContext(WithPrimary(ctx), querier, query, args...)
+	if err != nil {
+		return err
+	}
+	defer closeRows(rows, &err)
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to iterate rows: %w", err)
+		}
+
+		return errors.New("failed to insert row: no rows returned")
+	}
+
+	dest := make([]any, len(autos))
+	for i, fm := range autos {
+		dest[i] = fieldByIndexAlloc(rv, fm.index).Addr().Interface()
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates the row of the table identified by the fields with the pk option of the struct pointed to by v.
+// If columns are given, only they are set; otherwise, all the other fields are set
+// except those with the auto option and those with the omitempty option that have the zero value.
+//
+// It returns ErrNoRowsAffected if no row is updated.
+// Note that MySQL does not count a row whose values are unchanged as updated
+// unless `clientFoundRows=true` is set in the DSN (see RowsExpectation.AllowUnchanged).
+func Update(ctx context.Context, queryExecutor QueryExecutor, dialect Dialect, table Fragment, v any, columns ...string) error {
+	if err := dialect.validate(); err != nil {
+		return err
+	}
+	if table.IsZero() {
+		return errors.New("invalid table: empty")
+	}
+
+	rv, meta, err := structPointerOf(v)
+	if err != nil {
+		return err
+	}
+
+	var pks, sets []fieldMeta
+	for _, fm := range meta.fields {
+		if fm.pk {
+			pks = append(pks, fm)
+		}
+	}
+	if len(pks) == 0 {
+		return fmt.Errorf("invalid db tag: %s has no pk field", rv.Type())
+	}
+
+	valueOf := func(fm fieldMeta) (any, bool) {
+		fv, err := rv.FieldByIndexErr(fm.index)
+		if err != nil {
+			// through a nil embedded pointer
+			return nil, false
+		}
+
+		return fv.Interface(), fv.IsZero()
+	}
+
+	if len(columns) > 0 {
+		for _, col := range columns {
+			fi, ok := meta.lookup(col)
+			if !ok {
+				return fmt.Errorf("invalid columns: %s has no field for column: %s", rv.Type(), col)
+			}
+			if meta.fields[fi].pk {
+				return fmt.Errorf("invalid columns: pk column: %s", col)
+			}
+
+			sets = append(sets, meta.fields[fi])
+		}
+	} else {
+		for _, fm := range meta.fields {
+			if fm.pk || fm.auto {
+				continue
+			}
+			if _, zero := valueOf(fm); fm.omitEmpty && zero {
+				continue
+			}
+
+			sets = append(sets, fm)
+		}
+	}
+	if len(sets) == 0 {
+		return errors.New("invalid columns: no columns to update")
+	}
+
+	var (
+		sb   strings.Builder
+		args []any
+	)
+	placeholder := func(fm fieldMeta) string {
+		v, _ := valueOf(fm)
+		args = append(args, v)
+
+		return dialect.Placeholder(len(args))
+	}
+
+	sb.WriteString("UPDATE ")
+	sb.WriteString(table.String())
+	sb.WriteString(" SET ")
+	for i, fm := range sets {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(dialect.QuoteIdentifier(fm.name) + " = " + placeholder(fm))
+	}
+	sb.WriteString(" WHERE ")
+	for i, fm := range pks {
+		if i > 0 {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString(dialect.QuoteIdentifier(fm.name) + " = " + placeholder(fm))
+	}
+
+	_, err = ExecOne(ctx, queryExecutor, sb.String(), args...)
+
+	return err
+}
+
+// structPointerOf returns the struct pointed to by v and its metadata.
+func structPointerOf(v any) (reflect.Value, *structMeta, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || !isStructType(rv.Type().Elem()) {
+		return reflect.Value{}, nil, fmt.Errorf("unsupported type: %T: a pointer to a struct is required", v)
+	}
+	if rv.IsNil() {
+		return reflect.Value{}, nil, fmt.Errorf("unsupported type: nil %T", v)
+	}
+	rv = rv.Elem()
+
+	meta, err := structMetaOf(rv.Type())
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+
+	return rv, meta, nil
+}
@@ -0,0 +1,284 @@
+package sqlutil_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m0t0k1ch1-go/sqlutil/v3"
+)
+
+type writeTestTask struct {
+	ID          int64           `db:"id,pk,auto"`
+	Title       string          `db:"title"`
+	URL         sqlutil.HTTPURL `db:"url,omitempty"`
+	IsCompleted bool            `db:"is_completed,auto"`
+	Note        string          `db:"-"`
+}
+
+type writeTestIDTask struct {
+	ID    sqlutil.ID[writeTestIDTask] `db:"id,pk,auto"`
+	Title string                      `db:"title"`
+	URL   sqlutil.HTTPURL             `db:"url"`
+}
+
+type writeTestStringIDTask struct {
+	ID    string `db:"id,pk,auto"`
+	Title string `db:"title"`
+}
+
+type writeTestQueryExecutor struct {
+	query *string
+}
+
+func (qe writeTestQueryExecutor) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	*qe.query = query
+
+	return execTestResult(1), nil
+}
+
+func TestInsert(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name    string
+			dialect sqlutil.Dialect
+			in      any
+			want    string
+		}{
+			{
+				"not a pointer",
+				sqlutil.DialectMySQL,
+				writeTestTask{},
+				"unsupported type: sqlutil_test.writeTestTask: a pointer to a struct is required",
+			},
+			{
+				"nil",
+				sqlutil.DialectMySQL,
+				(*writeTestTask)(nil),
+				"unsupported type: nil *sqlutil_test.writeTestTask",
+			},
+			{
+				"returning without Querier",
+				sqlutil.DialectPostgreSQL,
+				&writeTestTask{},
+				"does not implement Querier",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				err := sqlutil.Insert(t.Context(), execTestQueryExecutor{1}, tc.dialect, tc.dialect.Identifier("auto_task"), tc.in)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	t.Run("failure: unsupported last insert id type", func(t *testing.T) {
+		var query string
+		err := sqlutil.Insert(t.Context(), writeTestQueryExecutor{&query}, sqlutil.DialectMySQL, sqlutil.DialectMySQL.Identifier("auto_task"), &writeTestStringIDTask{
+			Title: "task1",
+		})
+		require.ErrorContains(t, err, "unsupported type: string: last insert id requires an integer or a sql.Scanner")
+		require.Empty(t, query) // not executed
+	})
+
+	t.Run("success: auto fields skipped", func(t *testing.T) {
+		var query string
+		err := sqlutil.Update(t.Context(), writeTestQueryExecutor{&query}, sqlutil.DialectMySQL, sqlutil.DialectMySQL.Identifier("auto_task"), &writeTestTask{
+			ID:          1,
+			Title:       "task1",
+			URL:         sqlutil.MustNewHTTPURLFromString("http://m0t0k1ch1.com/task/1"),
+			IsCompleted: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE `auto_task` SET `title` = ?, `url` = ? WHERE `id` = ?", query)
+	})
+
+	tcs := []struct {
+		name    string
+		db      *sql.DB
+		dialect sqlutil.Dialect
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			sqlutil.DialectMySQL,
+		},
+		{
+			"postgresql",
+			psqlDB,
+			sqlutil.DialectPostgreSQL,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupAutoTasks(t, tc.db)
+
+			ctx := t.Context()
+
+			table := tc.dialect.Identifier("auto_task")
+
+			task1 := &writeTestTask{
+				Title:       "task1",
+				URL:         sqlutil.MustNewHTTPURLFromString("http://m0t0k1ch1.com/task/1"),
+				IsCompleted: true, // ignored
+			}
+			err := sqlutil.Insert(ctx, tc.db, tc.dialect, table, task1)
+			require.NoError(t, err)
+			require.NotZero(t, task1.ID)
+
+			task2 := &writeTestTask{
+				Title: "task2",
+				URL:   sqlutil.MustNewHTTPURLFromString("http://m0t0k1ch1.com/task/2"),
+			}
+			err = sqlutil.Transact(ctx, tc.db, func(ctx context.Context, tx *sql.Tx) error {
+				return sqlutil.Insert(ctx, tx, tc.dialect, table, task2)
+			})
+			require.NoError(t, err)
+			require.Greater(t, task2.ID, task1.ID)
+
+			task, err := sqlutil.QueryOne[writeTestTask](ctx, tc.db, `SELECT id, title, url, is_completed FROM auto_task WHERE id = `+tc.dialect.Placeholder(1), task1.ID)
+			require.NoError(t, err)
+			require.Equal(t, "task1", task.Title)
+			require.Equal(t, "http://m0t0k1ch1.com/task/1", task.URL.String())
+			require.False(t, task.IsCompleted)
+
+			task3 := &writeTestIDTask{
+				Title: "task3",
+				URL:   sqlutil.MustNewHTTPURLFromString("http://m0t0k1ch1.com/task/3"),
+			}
+			err = sqlutil.Insert(ctx, tc.db, tc.dialect, table, task3)
+			require.NoError(t, err)
+			require.Greater(t, task3.ID.Int64(), task2.ID)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		tcs := []struct {
+			name    string
+			in      any
+			columns []string
+			want    string
+		}{
+			{
+				"no pk field",
+				&Task{},
+				nil,
+				"invalid db tag: sqlutil_test.Task has no pk field",
+			},
+			{
+				"unknown column",
+				&writeTestTask{},
+				[]string{"note"},
+				"invalid columns: sqlutil_test.writeTestTask has no field for column: note",
+			},
+			{
+				"pk column",
+				&writeTestTask{},
+				[]string{"id"},
+				"invalid columns: pk column: id",
+			},
+		}
+
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				err := sqlutil.Update(t.Context(), execTestQueryExecutor{1}, sqlutil.DialectMySQL, sqlutil.DialectMySQL.Identifier("auto_task"), tc.in, tc.columns...)
+				require.ErrorContains(t, err, tc.want)
+			})
+		}
+	})
+
+	tcs := []struct {
+		name    string
+		db      *sql.DB
+		dialect sqlutil.Dialect
+	}{
+		{
+			"mysql",
+			mysqlDB,
+			sqlutil.DialectMySQL,
+		},
+		{
+			"postgresql",
+			psqlDB,
+			sqlutil.DialectPostgreSQL,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			setupAutoTasks(t, tc.db)
+
+			ctx := t.Context()
+
+			table := tc.dialect.Identifier("auto_task")
+
+			task := &writeTestTask{
+				Title: "task1",
+				URL:   sqlutil.MustNewHTTPURLFromString("http://m0t0k1ch1.com/task/1"),
+			}
+			err := sqlutil.Insert(ctx, tc.db, tc.dialect, table, task)
+			require.NoError(t, err)
+
+			getTask := func(t *testing.T) writeTestTask {
+				t.Helper()
+
+				task, err := sqlutil.QueryOne[writeTestTask](ctx, tc.db, `SELECT id, title, url, is_completed FROM auto_task WHERE id = `+tc.dialect.Placeholder(1), task.ID)
+				require.NoError(t, err)
+
+				return task
+			}
+
+			t.Run("failure: no rows affected", func(t *testing.T) {
+				err := sqlutil.Update(ctx, tc.db, tc.dialect, table, &writeTestTask{
+					ID:    task.ID + 100,
+					Title: "missing",
+				})
+				require.ErrorIs(t, err, sqlutil.ErrNoRowsAffected)
+			})
+
+			t.Run("success: field mask", func(t *testing.T) {
+				err := sqlutil.Update(ctx, tc.db, tc.dialect, table, &writeTestTask{
+					ID:          task.ID,
+					Title:       "ignored",
+					IsCompleted: true,
+				}, "is_completed")
+				require.NoError(t, err)
+
+				got := getTask(t)
+				require.Equal(t, "task1", got.Title)
+				require.True(t, got.IsCompleted)
+			})
+
+			t.Run("success: all fields", func(t *testing.T) {
+				err := sqlutil.Update(ctx, tc.db, tc.dialect, table, &writeTestTask{
+					ID:    task.ID,
+					Title: "task1 updated",
+				})
+				require.NoError(t, err)
+
+				got := getTask(t)
+				require.Equal(t, "task1 updated", got.Title)
+				require.Equal(t, "http://m0t0k1ch1.com/task/1", got.URL.String())
+				require.True(t, got.IsCompleted)
+			})
+		})
+	}
+}
+
+func setupAutoTasks(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		// should not use t.Context()
+		ctx := context.Background()
+
+		_, err := db.ExecContext(ctx, `TRUNCATE auto_task`)
+		require.NoError(t, err)
+	})
+}